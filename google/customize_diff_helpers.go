@@ -0,0 +1,1156 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// TerraformResourceDiff is the subset of *schema.ResourceDiff used by the
+// CustomizeDiff helpers in this file, so that they can be tested without
+// building a full diff.
+type TerraformResourceDiff interface {
+	HasChange(string) bool
+	GetChange(string) (interface{}, interface{})
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+	Id() string
+}
+
+// loggingBucketLockDiff prevents shortening the retention period of a logging
+// bucket once it has been locked.
+func loggingBucketLockDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return loggingBucketLockDiffFunc(d)
+}
+
+func loggingBucketLockDiffFunc(d TerraformResourceDiff) error {
+	oldLocked, _ := d.GetChange("locked")
+	if locked, _ := oldLocked.(bool); !locked {
+		return nil
+	}
+
+	o, n := d.GetChange("retention_days")
+	oldDays, _ := o.(int)
+	newDays, _ := n.(int)
+	if newDays < oldDays {
+		return fmt.Errorf("retention_days can not be decreased from %d to %d on a locked bucket", oldDays, newDays)
+	}
+	return nil
+}
+
+// ipWithinCIDRDiff checks that the IP address in ipKey falls within the range
+// in cidrKey. Unset values are skipped, as are values that don't parse: those
+// are either unknown until apply or already reported by the field validators.
+func ipWithinCIDRDiff(ipKey, cidrKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return ipWithinCIDRDiffFunc(d, ipKey, cidrKey)
+	}
+}
+
+func ipWithinCIDRDiffFunc(d TerraformResourceDiff, ipKey, cidrKey string) error {
+	rawIP, _ := d.Get(ipKey).(string)
+	rawCIDR, _ := d.Get(cidrKey).(string)
+	if rawIP == "" || rawCIDR == "" {
+		return nil
+	}
+
+	ip := net.ParseIP(rawIP)
+	_, ipnet, err := net.ParseCIDR(rawCIDR)
+	if ip == nil || err != nil {
+		return nil
+	}
+
+	if !ipnet.Contains(ip) {
+		return fmt.Errorf("%s (%s) is not within %s (%s)", ipKey, rawIP, cidrKey, rawCIDR)
+	}
+	return nil
+}
+
+// bigtableDevelopmentDiff enforces the restrictions on DEVELOPMENT Bigtable
+// instances, which run a single cluster with no configurable node count.
+func bigtableDevelopmentDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return bigtableDevelopmentDiffFunc(d)
+}
+
+func bigtableDevelopmentDiffFunc(d TerraformResourceDiff) error {
+	if d.Get("instance_type") != "DEVELOPMENT" {
+		return nil
+	}
+
+	if n, _ := d.Get("num_nodes").(int); n > 0 {
+		return fmt.Errorf("DEVELOPMENT Bigtable instances can't set num_nodes, got %d", n)
+	}
+
+	clusters, _ := d.Get("cluster").([]interface{})
+	if len(clusters) > 1 {
+		return fmt.Errorf("DEVELOPMENT Bigtable instances can only have one cluster, got %d", len(clusters))
+	}
+	for _, raw := range clusters {
+		cluster, _ := raw.(map[string]interface{})
+		if n, _ := cluster["num_nodes"].(int); n > 0 {
+			return fmt.Errorf("DEVELOPMENT Bigtable instances can't set num_nodes on their cluster, got %d", n)
+		}
+	}
+	return nil
+}
+
+// minMaxReplicasDiff checks that the replica count in minKey isn't larger than
+// the one in maxKey. Negative counts are left to the field validators.
+func minMaxReplicasDiff(minKey, maxKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return minMaxReplicasDiffFunc(d, minKey, maxKey)
+	}
+}
+
+func minMaxReplicasDiffFunc(d TerraformResourceDiff, minKey, maxKey string) error {
+	min, minOk := d.Get(minKey).(int)
+	max, maxOk := d.Get(maxKey).(int)
+	if !minOk || !maxOk {
+		return nil
+	}
+
+	if min > max {
+		return fmt.Errorf("%s (%d) must not be greater than %s (%d)", minKey, min, maxKey, max)
+	}
+	return nil
+}
+
+// dnsVisibilityDiff rejects private_visibility_config on managed zones that
+// aren't private. An unset visibility defaults to public.
+func dnsVisibilityDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return dnsVisibilityDiffFunc(d)
+}
+
+func dnsVisibilityDiffFunc(d TerraformResourceDiff) error {
+	config, _ := d.Get("private_visibility_config").([]interface{})
+	if len(config) == 0 {
+		return nil
+	}
+
+	if visibility, _ := d.Get("visibility").(string); visibility != "private" {
+		if visibility == "" {
+			visibility = "public"
+		}
+		return fmt.Errorf("private_visibility_config can only be set when visibility is \"private\", got %q", visibility)
+	}
+	return nil
+}
+
+// notificationChannelLabelsDiff checks that a notification channel sets the
+// labels required by its type, as listed in channelRequiredLabels.
+func notificationChannelLabelsDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return notificationChannelLabelsDiffFunc(d)
+}
+
+func notificationChannelLabelsDiffFunc(d TerraformResourceDiff) error {
+	channelType, _ := d.Get("type").(string)
+	labels, _ := d.Get("labels").(map[string]interface{})
+
+	var missing []string
+	for _, label := range channelRequiredLabels[channelType] {
+		if v, ok := labels[label]; !ok || v == "" {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("notification channels of type %q require labels: %s", channelType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// filestoreCapacityDiff checks each file share's capacity against the minimum
+// for the instance's tier, as listed in filestoreTierMinCapacityGb.
+func filestoreCapacityDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return filestoreCapacityDiffFunc(d)
+}
+
+func filestoreCapacityDiffFunc(d TerraformResourceDiff) error {
+	tier, _ := d.Get("tier").(string)
+	min, ok := filestoreTierMinCapacityGb[tier]
+	if !ok {
+		return nil
+	}
+
+	shares, _ := d.Get("file_shares").([]interface{})
+	for i, raw := range shares {
+		share, _ := raw.(map[string]interface{})
+		if capacity, ok := share["capacity_gb"].(int); ok && capacity < min {
+			return fmt.Errorf("file_shares.%d.capacity_gb (%d) must be at least %d for tier %s", i, capacity, min, tier)
+		}
+	}
+	return nil
+}
+
+// maintenanceWindowDiff checks a pair of HH:mm start and end times. The start
+// must be before the end, unless allowWrap is set, in which case a window may
+// wrap past midnight (start after end) or span a whole day (start equal to
+// end).
+func maintenanceWindowDiff(startKey, endKey string, allowWrap bool) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return maintenanceWindowDiffFunc(d, startKey, endKey, allowWrap)
+	}
+}
+
+func maintenanceWindowDiffFunc(d TerraformResourceDiff, startKey, endKey string, allowWrap bool) error {
+	start, _ := d.Get(startKey).(string)
+	end, _ := d.Get(endKey).(string)
+	if start == "" || end == "" {
+		return nil
+	}
+
+	if _, errs := validateRFC3339Time(start, startKey); len(errs) > 0 {
+		return errs[0]
+	}
+	if _, errs := validateRFC3339Time(end, endKey); len(errs) > 0 {
+		return errs[0]
+	}
+
+	// Both times are zero-padded HH:mm, so they compare correctly as strings.
+	if start < end || allowWrap {
+		return nil
+	}
+	return fmt.Errorf("%s (%s) must be before %s (%s), as this window can't wrap past midnight", startKey, start, endKey, end)
+}
+
+// sqlFlagValueDiff checks that the database flags listed in sqlBooleanFlags
+// are set to "on" or "off".
+func sqlFlagValueDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return sqlFlagValueDiffFunc(d)
+}
+
+func sqlFlagValueDiffFunc(d TerraformResourceDiff) error {
+	flags, _ := d.Get("settings.0.database_flags").([]interface{})
+	for _, raw := range flags {
+		flag, _ := raw.(map[string]interface{})
+		name, _ := flag["name"].(string)
+		value, _ := flag["value"].(string)
+		if sqlBooleanFlags[name] && value != "on" && value != "off" {
+			return fmt.Errorf("database flag %q is a boolean flag and must be set to \"on\" or \"off\", got %q", name, value)
+		}
+	}
+	return nil
+}
+
+// gkeAddonSetting is a cluster setting that is considered enabled when the
+// field at Key holds Value.
+type gkeAddonSetting struct {
+	Name  string
+	Key   string
+	Value interface{}
+}
+
+// gkeConflictingAddons lists pairs of cluster settings that can't be enabled
+// together.
+var gkeConflictingAddons = [][2]gkeAddonSetting{
+	{
+		{Name: "network_policy", Key: "network_policy.0.enabled", Value: true},
+		{Name: "Dataplane V2 (datapath_provider = ADVANCED_DATAPATH)", Key: "datapath_provider", Value: "ADVANCED_DATAPATH"},
+	},
+}
+
+// gkeAddonConflictsDiff rejects clusters that enable both settings of any pair
+// in gkeConflictingAddons.
+func gkeAddonConflictsDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return gkeAddonConflictsDiffFunc(d)
+}
+
+func gkeAddonConflictsDiffFunc(d TerraformResourceDiff) error {
+	for _, pair := range gkeConflictingAddons {
+		if d.Get(pair[0].Key) == pair[0].Value && d.Get(pair[1].Key) == pair[1].Value {
+			return fmt.Errorf("%s can't be enabled together with %s", pair[0].Name, pair[1].Name)
+		}
+	}
+	return nil
+}
+
+// networkSubnetModeDiff warns about networks that set the legacy ipv4_range
+// while auto_create_subnetworks is true. A network is either a legacy network
+// (ipv4_range set, auto_create_subnetworks false), an auto subnet mode network
+// (auto_create_subnetworks true) or a custom subnet mode network (neither set),
+// so this combination will fail on create.
+func networkSubnetModeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if warning := networkSubnetModeDiffFunc(d); warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
+
+func networkSubnetModeDiffFunc(d TerraformResourceDiff) string {
+	auto, _ := d.Get("auto_create_subnetworks").(bool)
+	ipv4Range, _ := d.Get("ipv4_range").(string)
+	if auto && ipv4Range != "" {
+		return fmt.Sprintf("ipv4_range (%s) is only used by legacy networks and can't be set when auto_create_subnetworks is true", ipv4Range)
+	}
+	return ""
+}
+
+// nodePoolLocationsDiff checks that each zone in locationsKey is in the
+// cluster's region. The cluster location may be a region or a zone.
+func nodePoolLocationsDiff(clusterRegionKey, locationsKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return nodePoolLocationsDiffFunc(d, clusterRegionKey, locationsKey)
+	}
+}
+
+func nodePoolLocationsDiffFunc(d TerraformResourceDiff, clusterRegionKey, locationsKey string) error {
+	region, _ := d.Get(clusterRegionKey).(string)
+	if region == "" {
+		return nil
+	}
+	if _, errs := validateZone(region, clusterRegionKey); len(errs) == 0 {
+		region = getRegionFromZone(region)
+	}
+
+	for _, raw := range listOrSetValues(d.Get(locationsKey)) {
+		zone, _ := raw.(string)
+		if _, errs := validateZone(zone, locationsKey); len(errs) > 0 {
+			return errs[0]
+		}
+		if getRegionFromZone(zone) != region {
+			return fmt.Errorf("%s contains zone %q, which is not in the cluster's region %q", locationsKey, zone, region)
+		}
+	}
+	return nil
+}
+
+// listOrSetValues returns the elements of a TypeList or TypeSet field value.
+func listOrSetValues(v interface{}) []interface{} {
+	switch l := v.(type) {
+	case *schema.Set:
+		return l.List()
+	case []interface{}:
+		return l
+	}
+	return nil
+}
+
+// healthCheckTimingDiff checks that a health check times out before its next
+// check is due, and that its thresholds are positive.
+func healthCheckTimingDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return healthCheckTimingDiffFunc(d)
+}
+
+func healthCheckTimingDiffFunc(d TerraformResourceDiff) error {
+	timeout, timeoutOk := d.Get("timeout_sec").(int)
+	interval, intervalOk := d.Get("check_interval_sec").(int)
+	if timeoutOk && intervalOk && timeout > interval {
+		return fmt.Errorf("timeout_sec (%d) must be less than or equal to check_interval_sec (%d)", timeout, interval)
+	}
+
+	for _, k := range []string{"healthy_threshold", "unhealthy_threshold"} {
+		if n, ok := d.Get(k).(int); ok && n < 1 {
+			return fmt.Errorf("%s (%d) must be at least 1", k, n)
+		}
+	}
+	return nil
+}
+
+// sslPolicyCustomFeaturesDiff checks that custom_features are set if, and only
+// if, the SSL policy uses the CUSTOM profile.
+func sslPolicyCustomFeaturesDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return sslPolicyCustomFeaturesDiffFunc(d)
+}
+
+func sslPolicyCustomFeaturesDiffFunc(d TerraformResourceDiff) error {
+	profile, _ := d.Get("profile").(string)
+	features := listOrSetValues(d.Get("custom_features"))
+
+	if profile == "CUSTOM" && len(features) == 0 {
+		return fmt.Errorf("custom_features must be set when profile is CUSTOM")
+	}
+	if profile != "CUSTOM" && len(features) > 0 {
+		return fmt.Errorf("custom_features can only be set when profile is CUSTOM, got profile %q", profile)
+	}
+	return nil
+}
+
+// instanceSchedulePolicyDiff checks the start and stop schedules and time zone
+// of an instance schedule resource policy. The schedules are only meaningful
+// in a time zone, so at least one schedule and the time zone must be set
+// together.
+func instanceSchedulePolicyDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return instanceSchedulePolicyDiffFunc(d)
+}
+
+func instanceSchedulePolicyDiffFunc(d TerraformResourceDiff) error {
+	timeZoneKey := "instance_schedule_policy.0.time_zone"
+	scheduleKeys := []string{
+		"instance_schedule_policy.0.vm_start_schedule.0.schedule",
+		"instance_schedule_policy.0.vm_stop_schedule.0.schedule",
+	}
+
+	var scheduled bool
+	for _, k := range scheduleKeys {
+		schedule, _ := d.Get(k).(string)
+		if schedule == "" {
+			continue
+		}
+		scheduled = true
+		if _, errs := validateCronSchedule(schedule, k); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+
+	timeZone, _ := d.Get(timeZoneKey).(string)
+	if timeZone == "" {
+		if scheduled {
+			return fmt.Errorf("%s must be set along with a schedule", timeZoneKey)
+		}
+		return nil
+	}
+	if !scheduled {
+		return fmt.Errorf("%s is set, but neither %s nor %s is", timeZoneKey, scheduleKeys[0], scheduleKeys[1])
+	}
+	if _, errs := validateTimeZone(timeZone, timeZoneKey); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// weightedBackendSumDiff checks that the weightKey attribute of each element
+// in listKey adds up to 100, as traffic splits require. All zero weights are
+// also accepted, leaving the split to the API default.
+func weightedBackendSumDiff(listKey, weightKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return weightedBackendSumDiffFunc(d, listKey, weightKey)
+	}
+}
+
+func weightedBackendSumDiffFunc(d TerraformResourceDiff, listKey, weightKey string) error {
+	backends := listOrSetValues(d.Get(listKey))
+	if len(backends) == 0 {
+		return nil
+	}
+
+	sum := 0
+	for _, raw := range backends {
+		backend, _ := raw.(map[string]interface{})
+		weight, _ := backend[weightKey].(int)
+		sum += weight
+	}
+
+	if sum != 0 && sum != 100 {
+		return fmt.Errorf("the %s of each %s must add up to 100, got %d", weightKey, listKey, sum)
+	}
+	return nil
+}
+
+// autopilotConflictsDiff rejects node configuration on Autopilot clusters,
+// whose nodes are managed by GKE. Both fields are populated from the API on
+// read, so only values that change in this plan are checked.
+func autopilotConflictsDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return autopilotConflictsDiffFunc(d)
+}
+
+func autopilotConflictsDiffFunc(d TerraformResourceDiff) error {
+	if autopilot, _ := d.Get("enable_autopilot").(bool); !autopilot {
+		return nil
+	}
+
+	for _, k := range []string{"node_config", "node_pool"} {
+		if d.HasChange(k) && len(listOrSetValues(d.Get(k))) > 0 {
+			return fmt.Errorf("%s cannot be set when enable_autopilot is true, as GKE manages the nodes of Autopilot clusters", k)
+		}
+	}
+	return nil
+}
+
+// bucketLifecycleRuleDiff checks that each storage bucket lifecycle rule has at
+// least one condition, and that its age and created_before conditions are
+// valid.
+func bucketLifecycleRuleDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return bucketLifecycleRuleDiffFunc(d)
+}
+
+func bucketLifecycleRuleDiffFunc(d TerraformResourceDiff) error {
+	for i, raw := range listOrSetValues(d.Get("lifecycle_rule")) {
+		rule, _ := raw.(map[string]interface{})
+		var condition map[string]interface{}
+		if conditions := listOrSetValues(rule["condition"]); len(conditions) > 0 {
+			condition, _ = conditions[0].(map[string]interface{})
+		}
+
+		// An age of 0 is a valid condition matching every object, so age
+		// counts as set when it is present at all, as when the resource
+		// builds the API request.
+		rawAge, hasAge := condition["age"]
+		age, _ := rawAge.(int)
+		createdBefore, _ := condition["created_before"].(string)
+		isLive, _ := condition["is_live"].(bool)
+		numNewerVersions, _ := condition["num_newer_versions"].(int)
+		storageClasses := listOrSetValues(condition["matches_storage_class"])
+
+		if !hasAge && createdBefore == "" && !isLive && numNewerVersions == 0 && len(storageClasses) == 0 {
+			return fmt.Errorf("lifecycle_rule.%d must set at least one condition", i)
+		}
+		if age < 0 {
+			return fmt.Errorf("lifecycle_rule.%d.condition.age must not be negative, got %d", i, age)
+		}
+		if createdBefore != "" {
+			if _, err := time.Parse("2006-01-02", createdBefore); err != nil {
+				return fmt.Errorf("lifecycle_rule.%d.condition.created_before (%q) must be an RFC3339 date, such as \"2019-01-31\"", i, createdBefore)
+			}
+		}
+	}
+	return nil
+}
+
+// negPortDiff rejects a default_port on network endpoint groups whose
+// endpoints don't have a port, such as SERVERLESS NEGs.
+func negPortDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return negPortDiffFunc(d)
+}
+
+func negPortDiffFunc(d TerraformResourceDiff) error {
+	port, _ := d.Get("default_port").(int)
+	if port == 0 {
+		return nil
+	}
+
+	negType, _ := d.Get("network_endpoint_type").(string)
+	if negType == "" {
+		return nil
+	}
+	for _, t := range negTypesWithPort {
+		if t == negType {
+			return nil
+		}
+	}
+	return fmt.Errorf("default_port cannot be set for %s network endpoint groups, only for %s", negType, strings.Join(negTypesWithPort, ", "))
+}
+
+// pushOIDCDiff requires a service account to generate OIDC tokens with when a
+// push subscription sets an audience for them.
+func pushOIDCDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return pushOIDCDiffFunc(d)
+}
+
+func pushOIDCDiffFunc(d TerraformResourceDiff) error {
+	audienceKey := "push_config.0.oidc_token.0.audience"
+	emailKey := "push_config.0.oidc_token.0.service_account_email"
+
+	audience, _ := d.Get(audienceKey).(string)
+	email, _ := d.Get(emailKey).(string)
+	if audience != "" && email == "" {
+		return fmt.Errorf("%s must be set when %s is set", emailKey, audienceKey)
+	}
+	return nil
+}
+
+// reservationShareDiff only allows a reservation to list the projects it is
+// shared with when its share type is SPECIFIC_PROJECTS.
+func reservationShareDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return reservationShareDiffFunc(d)
+}
+
+func reservationShareDiffFunc(d TerraformResourceDiff) error {
+	shareType, _ := d.Get("share_settings.0.share_type").(string)
+	if shareType == "" || shareType == "SPECIFIC_PROJECTS" {
+		return nil
+	}
+
+	if len(listOrSetValues(d.Get("share_settings.0.project_map"))) > 0 {
+		return fmt.Errorf("share_settings.0.project_map can only be set when share_settings.0.share_type is SPECIFIC_PROJECTS, got %s", shareType)
+	}
+	return nil
+}
+
+// cloudRunTrafficDiff checks that each Cloud Run traffic block in listKey
+// targets either the latest revision or a named revision, but not both.
+func cloudRunTrafficDiff(listKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return cloudRunTrafficDiffFunc(d, listKey)
+	}
+}
+
+func cloudRunTrafficDiffFunc(d TerraformResourceDiff, listKey string) error {
+	for i, raw := range listOrSetValues(d.Get(listKey)) {
+		traffic, _ := raw.(map[string]interface{})
+		latest, _ := traffic["latest_revision"].(bool)
+		revision, _ := traffic["revision_name"].(string)
+
+		if latest && revision != "" {
+			return fmt.Errorf("%s.%d cannot set both latest_revision and revision_name", listKey, i)
+		}
+		if !latest && revision == "" {
+			return fmt.Errorf("%s.%d must set either latest_revision or revision_name", listKey, i)
+		}
+	}
+	return nil
+}
+
+// sqlBackupConfigDiff checks that engine specific backup settings are only
+// enabled for the engine they apply to: binary logging for MySQL, and point in
+// time recovery for PostgreSQL.
+func sqlBackupConfigDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return sqlBackupConfigDiffFunc(d)
+}
+
+func sqlBackupConfigDiffFunc(d TerraformResourceDiff) error {
+	version, _ := d.Get("database_version").(string)
+	if version == "" {
+		return nil
+	}
+
+	settings := []struct {
+		Key    string
+		Engine string
+	}{
+		{"settings.0.backup_configuration.0.binary_log_enabled", "MYSQL"},
+		{"settings.0.backup_configuration.0.point_in_time_recovery_enabled", "POSTGRES"},
+	}
+	for _, s := range settings {
+		if enabled, _ := d.Get(s.Key).(bool); enabled && !strings.HasPrefix(version, s.Engine+"_") {
+			return fmt.Errorf("%s can only be enabled for %s instances, got database_version %s", s.Key, s.Engine, version)
+		}
+	}
+	return nil
+}
+
+// dataprocSingleNodeDiff rejects workers on single node Dataproc clusters,
+// which are requested by setting the dataproc:dataproc.allow.zero.workers
+// property.
+func dataprocSingleNodeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return dataprocSingleNodeDiffFunc(d)
+}
+
+func dataprocSingleNodeDiffFunc(d TerraformResourceDiff) error {
+	properties, _ := d.Get("cluster_config.0.software_config.0.override_properties").(map[string]interface{})
+	if singleNode, _ := properties["dataproc:dataproc.allow.zero.workers"].(string); singleNode != "true" {
+		return nil
+	}
+
+	numWorkersKey := "cluster_config.0.worker_config.0.num_instances"
+	if numWorkers, _ := d.Get(numWorkersKey).(int); numWorkers > 0 {
+		return fmt.Errorf("%s must be 0 for single node clusters, got %d", numWorkersKey, numWorkers)
+	}
+	return nil
+}
+
+// requiresStopForUpdateDiff rejects changes to any of fields, which can only
+// be updated by stopping the instance, unless the allowKey field permits
+// Terraform to stop it. New instances are skipped, as they aren't running yet.
+func requiresStopForUpdateDiff(fields []string, allowKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return requiresStopForUpdateDiffFunc(d, fields, allowKey)
+	}
+}
+
+func requiresStopForUpdateDiffFunc(d TerraformResourceDiff, fields []string, allowKey string) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if allow, _ := d.Get(allowKey).(bool); allow {
+		return nil
+	}
+
+	for _, f := range fields {
+		if !d.HasChange(f) {
+			continue
+		}
+		return fmt.Errorf("changing %s requires stopping the instance, set %s = true to allow Terraform to stop it", f, allowKey)
+	}
+	return nil
+}
+
+// migTargetSizeAutoscalerDiff warns when target_size is set on a managed
+// instance group that the autoscaledKey field marks as autoscaled. An
+// autoscaler is attached from a separate resource, so the group can't detect
+// one itself, and relies on the configuration to say so.
+func migTargetSizeAutoscalerDiff(autoscaledKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if warning := migTargetSizeAutoscalerDiffFunc(d, autoscaledKey); warning != "" {
+			log.Printf("[WARN] %s", warning)
+		}
+		return nil
+	}
+}
+
+func migTargetSizeAutoscalerDiffFunc(d TerraformResourceDiff, autoscaledKey string) string {
+	if autoscaled, _ := d.Get(autoscaledKey).(bool); !autoscaled {
+		return ""
+	}
+	if _, ok := d.GetOk("target_size"); !ok || !d.HasChange("target_size") {
+		return ""
+	}
+
+	targetSize, _ := d.Get("target_size").(int)
+	return fmt.Sprintf("target_size is set to %d, but %s is true, so the autoscaler will override it. "+
+		"Leave target_size unset on autoscaled groups", targetSize, autoscaledKey)
+}
+
+// sqlReplicaDiff requires a master_instance_name when replica_configuration is
+// set, as only replicas can be configured as one.
+func sqlReplicaDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return sqlReplicaDiffFunc(d)
+}
+
+func sqlReplicaDiffFunc(d TerraformResourceDiff) error {
+	if len(listOrSetValues(d.Get("replica_configuration"))) == 0 {
+		return nil
+	}
+	if master, _ := d.Get("master_instance_name").(string); master == "" {
+		return fmt.Errorf("replica_configuration can only be set on a replica, which requires master_instance_name to be set")
+	}
+	return nil
+}
+
+// firewallLogConfigDiff rejects log_config metadata on firewalls that don't
+// have logging enabled, as it would have no effect.
+func firewallLogConfigDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return firewallLogConfigDiffFunc(d)
+}
+
+func firewallLogConfigDiffFunc(d TerraformResourceDiff) error {
+	metadata, _ := d.Get("log_config.0.metadata").(string)
+	if metadata == "" {
+		return nil
+	}
+	if enabled, _ := d.Get("enable_logging").(bool); !enabled {
+		return fmt.Errorf("log_config.0.metadata (%s) can only be set when enable_logging is true", metadata)
+	}
+	return nil
+}
+
+// natSubnetworkDiff only allows a Cloud NAT to list subnetworks when its
+// source_subnetwork_ip_ranges_to_nat is LIST_OF_SUBNETWORKS; the other modes
+// apply to every subnetwork in the region.
+func natSubnetworkDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return natSubnetworkDiffFunc(d)
+}
+
+func natSubnetworkDiffFunc(d TerraformResourceDiff) error {
+	mode, _ := d.Get("source_subnetwork_ip_ranges_to_nat").(string)
+	if mode == "" || mode == "LIST_OF_SUBNETWORKS" {
+		return nil
+	}
+	if len(listOrSetValues(d.Get("subnetwork"))) > 0 {
+		return fmt.Errorf("subnetwork can only be set when source_subnetwork_ip_ranges_to_nat is LIST_OF_SUBNETWORKS, got %s", mode)
+	}
+	return nil
+}
+
+// cloudRunScalingDiff checks that a Cloud Run service's minimum instance count
+// is no greater than its maximum.
+func cloudRunScalingDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return cloudRunScalingDiffFunc(d)
+}
+
+func cloudRunScalingDiffFunc(d TerraformResourceDiff) error {
+	return minMaxReplicasDiffFunc(d, "template.0.scaling.0.min_instance_count", "template.0.scaling.0.max_instance_count")
+}
+
+// releaseChannelVersionDiff warns when a cluster enrolled in a release channel
+// also pins min_master_version, as the channel upgrades the master on its own
+// schedule and may conflict with the pinned version.
+func releaseChannelVersionDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if warning := releaseChannelVersionDiffFunc(d); warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
+
+func releaseChannelVersionDiffFunc(d TerraformResourceDiff) string {
+	channel, _ := d.Get("release_channel.0.channel").(string)
+	version, _ := d.Get("min_master_version").(string)
+	if channel == "" || channel == "UNSPECIFIED" || version == "" {
+		return ""
+	}
+	return fmt.Sprintf("min_master_version (%s) is set on a cluster in the %s release channel, "+
+		"which manages master upgrades and may conflict with it", version, channel)
+}
+
+// forwardingRuleIPVersionDiff checks that a forwarding rule's ip_address is of
+// the family its ip_version asks for. Addresses that don't parse as an IP,
+// such as references to an address resource, are skipped.
+func forwardingRuleIPVersionDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return forwardingRuleIPVersionDiffFunc(d)
+}
+
+func forwardingRuleIPVersionDiffFunc(d TerraformResourceDiff) error {
+	version, _ := d.Get("ip_version").(string)
+	address, _ := d.Get("ip_address").(string)
+	ip := net.ParseIP(address)
+	if version == "" || ip == nil {
+		return nil
+	}
+
+	family := "IPV6"
+	if ip.To4() != nil {
+		family = "IPV4"
+	}
+	if family != version {
+		return fmt.Errorf("ip_address (%s) is an %s address, but ip_version is %s", address, family, version)
+	}
+	return nil
+}
+
+// instanceIPForwardAliasDiff warns when an instance has alias IP ranges but
+// can't forward IP packets, as traffic routed to the instance for addresses it
+// doesn't own would then be dropped.
+func instanceIPForwardAliasDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if warning := instanceIPForwardAliasDiffFunc(d); warning != "" {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
+
+func instanceIPForwardAliasDiffFunc(d TerraformResourceDiff) string {
+	if forward, _ := d.Get("can_ip_forward").(bool); forward {
+		return ""
+	}
+
+	for i, raw := range listOrSetValues(d.Get("network_interface")) {
+		networkInterface, _ := raw.(map[string]interface{})
+		if len(listOrSetValues(networkInterface["alias_ip_range"])) > 0 {
+			return fmt.Sprintf("network_interface.%d.alias_ip_range is set but can_ip_forward is false, "+
+				"consider setting can_ip_forward = true if the instance routes traffic for other addresses", i)
+		}
+	}
+	return ""
+}
+
+// sqlDiskAutoresizeDiff checks that a Cloud SQL instance's autoresize limit
+// isn't below its disk size. A limit of 0 means there is no limit.
+func sqlDiskAutoresizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return sqlDiskAutoresizeDiffFunc(d)
+}
+
+func sqlDiskAutoresizeDiffFunc(d TerraformResourceDiff) error {
+	// disk_autoresize defaults to true, so only skip when it is explicitly off.
+	if autoresize, ok := d.Get("settings.0.disk_autoresize").(bool); ok && !autoresize {
+		return nil
+	}
+
+	limit, _ := d.Get("settings.0.disk_autoresize_limit").(int)
+	size, _ := d.Get("settings.0.disk_size").(int)
+	if limit != 0 && limit < size {
+		return fmt.Errorf("settings.0.disk_autoresize_limit (%d) must not be less than settings.0.disk_size (%d)", limit, size)
+	}
+	return nil
+}
+
+var routeNextHopFields = []string{
+	"next_hop_gateway",
+	"next_hop_instance",
+	"next_hop_ip",
+	"next_hop_vpn_tunnel",
+	"next_hop_ilb",
+}
+
+// routeNextHopDiff checks that a route sets exactly one next hop.
+func routeNextHopDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return routeNextHopDiffFunc(d)
+}
+
+func routeNextHopDiffFunc(d TerraformResourceDiff) error {
+	var set []string
+	for _, f := range routeNextHopFields {
+		if v, _ := d.Get(f).(string); v != "" {
+			set = append(set, f)
+		}
+	}
+
+	switch len(set) {
+	case 1:
+		return nil
+	case 0:
+		return fmt.Errorf("exactly one of %s must be set", strings.Join(routeNextHopFields, ", "))
+	}
+	return fmt.Errorf("exactly one next hop must be set, got %s", strings.Join(set, ", "))
+}
+
+// diskEncryptionKeyDiff checks that a disk is encrypted with either a customer
+// supplied key or a KMS key, but not both, and that the KMS key is a relative
+// resource name.
+func diskEncryptionKeyDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return diskEncryptionKeyDiffFunc(d)
+}
+
+func diskEncryptionKeyDiffFunc(d TerraformResourceDiff) error {
+	raw, _ := d.Get("disk_encryption_key_raw").(string)
+	kmsKey, _ := d.Get("kms_key_self_link").(string)
+	if kmsKey == "" {
+		return nil
+	}
+	if raw != "" {
+		return fmt.Errorf("only one of disk_encryption_key_raw and kms_key_self_link can be set")
+	}
+
+	template := "projects/{project}/locations/{location}/keyRings/{keyRing}/cryptoKeys/{cryptoKey}"
+	if _, errs := validateRelativeResourceName(template)(kmsKey, "kms_key_self_link"); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// managedCertMaxDomains is the most domains a Google-managed SSL certificate
+// can cover.
+const managedCertMaxDomains = 100
+
+// managedCertDomainCountDiff limits the number of domains on a managed SSL
+// certificate.
+func managedCertDomainCountDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return managedCertDomainCountDiffFunc(d)
+}
+
+func managedCertDomainCountDiffFunc(d TerraformResourceDiff) error {
+	if n := len(listOrSetValues(d.Get("managed.0.domains"))); n > managedCertMaxDomains {
+		return fmt.Errorf("managed.0.domains can have at most %d domains, got %d", managedCertMaxDomains, n)
+	}
+	return nil
+}
+
+// gpuMaintenanceDiff requires instances with GPUs attached to terminate on
+// host maintenance, as instances with GPUs can't be live migrated.
+func gpuMaintenanceDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return gpuMaintenanceDiffFunc(d)
+}
+
+func gpuMaintenanceDiffFunc(d TerraformResourceDiff) error {
+	var gpus int
+	for _, raw := range listOrSetValues(d.Get("guest_accelerator")) {
+		accelerator, _ := raw.(map[string]interface{})
+		count, _ := accelerator["count"].(int)
+		gpus += count
+	}
+	if gpus == 0 {
+		return nil
+	}
+
+	if maintenance, _ := d.Get("scheduling.0.on_host_maintenance").(string); maintenance != "TERMINATE" {
+		return fmt.Errorf("scheduling.0.on_host_maintenance must be TERMINATE for instances with guest accelerators, "+
+			"as instances with GPUs can't be live migrated, got %q", maintenance)
+	}
+	return nil
+}
+
+// spotSchedulingDiff rejects automatic_restart on preemptible and Spot
+// instances, which GCE stops rather than restarts.
+func spotSchedulingDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return spotSchedulingDiffFunc(d)
+}
+
+func spotSchedulingDiffFunc(d TerraformResourceDiff) error {
+	restartKey := "scheduling.0.automatic_restart"
+	if restart, _ := d.Get(restartKey).(bool); !restart {
+		return nil
+	}
+
+	if preemptible, _ := d.Get("scheduling.0.preemptible").(bool); preemptible {
+		return fmt.Errorf("%s must be false when scheduling.0.preemptible is true", restartKey)
+	}
+	if model, _ := d.Get("scheduling.0.provisioning_model").(string); model == "SPOT" {
+		return fmt.Errorf("%s must be false when scheduling.0.provisioning_model is SPOT", restartKey)
+	}
+	return nil
+}
+
+// cloudRunVolumeMountDiff checks that each volume mounted by a Cloud Run
+// container is declared in the service's volumes.
+func cloudRunVolumeMountDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return cloudRunVolumeMountDiffFunc(d)
+}
+
+func cloudRunVolumeMountDiffFunc(d TerraformResourceDiff) error {
+	volumes := make(map[string]bool)
+	for _, raw := range listOrSetValues(d.Get("template.0.volumes")) {
+		volume, _ := raw.(map[string]interface{})
+		name, _ := volume["name"].(string)
+		volumes[name] = true
+	}
+
+	for i, raw := range listOrSetValues(d.Get("template.0.containers")) {
+		container, _ := raw.(map[string]interface{})
+		for _, rawMount := range listOrSetValues(container["volume_mounts"]) {
+			mount, _ := rawMount.(map[string]interface{})
+			if name, _ := mount["name"].(string); name != "" && !volumes[name] {
+				return fmt.Errorf("template.0.containers.%d mounts volume %q, which isn't declared in template.0.volumes", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+var healthCheckTypeBlocks = []string{
+	"http_health_check",
+	"https_health_check",
+	"tcp_health_check",
+	"ssl_health_check",
+	"grpc_health_check",
+}
+
+// healthCheckTypeDiff checks that a health check defines exactly one type of
+// check.
+func healthCheckTypeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return healthCheckTypeDiffFunc(d)
+}
+
+func healthCheckTypeDiffFunc(d TerraformResourceDiff) error {
+	var set []string
+	for _, b := range healthCheckTypeBlocks {
+		if len(listOrSetValues(d.Get(b))) > 0 {
+			set = append(set, b)
+		}
+	}
+
+	switch len(set) {
+	case 1:
+		return nil
+	case 0:
+		return fmt.Errorf("exactly one of %s must be set", strings.Join(healthCheckTypeBlocks, ", "))
+	}
+	return fmt.Errorf("exactly one type of health check must be set, got %s", strings.Join(set, ", "))
+}
+
+// routerAdvertiseDiff only allows a Cloud Router to list custom advertisements
+// when its BGP advertise_mode is CUSTOM.
+func routerAdvertiseDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return routerAdvertiseDiffFunc(d)
+}
+
+func routerAdvertiseDiffFunc(d TerraformResourceDiff) error {
+	mode, _ := d.Get("bgp.0.advertise_mode").(string)
+	if mode == "CUSTOM" {
+		return nil
+	}
+	if mode == "" {
+		mode = "DEFAULT"
+	}
+
+	for _, k := range []string{"bgp.0.advertised_groups", "bgp.0.advertised_ip_ranges"} {
+		if len(listOrSetValues(d.Get(k))) > 0 {
+			return fmt.Errorf("%s can only be set when bgp.0.advertise_mode is CUSTOM, got %s", k, mode)
+		}
+	}
+	return nil
+}
+
+var templateDiskSourceFields = []string{"source", "source_image", "source_snapshot"}
+
+// templateDiskSourceDiff checks that each instance template disk in listKey
+// sets at most one of source, source_image and source_snapshot, and at least
+// one unless it is a scratch disk or a blank disk sized with disk_size_gb.
+func templateDiskSourceDiff(listKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		return templateDiskSourceDiffFunc(d, listKey)
+	}
+}
+
+func templateDiskSourceDiffFunc(d TerraformResourceDiff, listKey string) error {
+	for i, raw := range listOrSetValues(d.Get(listKey)) {
+		disk, _ := raw.(map[string]interface{})
+		var set []string
+		for _, f := range templateDiskSourceFields {
+			if v, _ := disk[f].(string); v != "" {
+				set = append(set, f)
+			}
+		}
+
+		if len(set) > 1 {
+			return fmt.Errorf("%s.%d can only set one of %s, got %s", listKey, i,
+				strings.Join(templateDiskSourceFields, ", "), strings.Join(set, ", "))
+		}
+		if len(set) == 0 {
+			diskType, _ := disk["type"].(string)
+			size, _ := disk["disk_size_gb"].(int)
+			if diskType != "SCRATCH" && size == 0 {
+				return fmt.Errorf("%s.%d must set one of %s, or disk_size_gb for a blank disk", listKey, i,
+					strings.Join(templateDiskSourceFields, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// schedulerRetryBackoffDiff checks that a Cloud Scheduler job's minimum retry
+// backoff is no longer than its maximum. Unset or invalid durations are
+// skipped, the latter being reported by validateDuration.
+func schedulerRetryBackoffDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return schedulerRetryBackoffDiffFunc(d)
+}
+
+func schedulerRetryBackoffDiffFunc(d TerraformResourceDiff) error {
+	minKey := "retry_config.0.min_backoff_duration"
+	maxKey := "retry_config.0.max_backoff_duration"
+	minRaw, _ := d.Get(minKey).(string)
+	maxRaw, _ := d.Get(maxKey).(string)
+	if minRaw == "" || maxRaw == "" {
+		return nil
+	}
+	if _, errs := validateDuration(minRaw, minKey); len(errs) > 0 {
+		return nil
+	}
+	if _, errs := validateDuration(maxRaw, maxKey); len(errs) > 0 {
+		return nil
+	}
+
+	min, _ := time.ParseDuration(minRaw)
+	max, _ := time.ParseDuration(maxRaw)
+	if min > max {
+		return fmt.Errorf("%s (%s) must not be longer than %s (%s)", minKey, minRaw, maxKey, maxRaw)
+	}
+	return nil
+}
+
+// firewallTargetDiff rejects firewalls that select instances by both network
+// tags and service accounts, which the API doesn't allow on either side of a
+// rule.
+func firewallTargetDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return firewallTargetDiffFunc(d)
+}
+
+func firewallTargetDiffFunc(d TerraformResourceDiff) error {
+	for _, pair := range [][2]string{
+		{"target_tags", "target_service_accounts"},
+		{"source_tags", "source_service_accounts"},
+	} {
+		if len(listOrSetValues(d.Get(pair[0]))) > 0 && len(listOrSetValues(d.Get(pair[1]))) > 0 {
+			return fmt.Errorf("%s and %s are mutually exclusive, only one of them may be set", pair[0], pair[1])
+		}
+	}
+	return nil
+}
+
+// globalAddressPeeringDiff only allows a global address to set prefix_length
+// when it reserves an internal range for VPC peering, such as for private
+// services access.
+func globalAddressPeeringDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return globalAddressPeeringDiffFunc(d)
+}
+
+func globalAddressPeeringDiffFunc(d TerraformResourceDiff) error {
+	prefixLength, _ := d.Get("prefix_length").(int)
+	if prefixLength == 0 {
+		return nil
+	}
+	if purpose, _ := d.Get("purpose").(string); purpose != "VPC_PEERING" {
+		return fmt.Errorf("prefix_length (%d) can only be set when purpose is VPC_PEERING, as it sizes the internal range reserved for peering", prefixLength)
+	}
+	return nil
+}
+
+// eventarcCriteriaDiff checks that an Eventarc trigger filters events with at
+// least one matching_criteria block, one of which selects the event type.
+func eventarcCriteriaDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return eventarcCriteriaDiffFunc(d)
+}
+
+func eventarcCriteriaDiffFunc(d TerraformResourceDiff) error {
+	criteria := listOrSetValues(d.Get("matching_criteria"))
+	if len(criteria) == 0 {
+		return fmt.Errorf("a matching_criteria block is required, with at least one criteria for the \"type\" attribute")
+	}
+
+	for _, raw := range criteria {
+		criterion, _ := raw.(map[string]interface{})
+		if attribute, _ := criterion["attribute"].(string); attribute == "type" {
+			return nil
+		}
+	}
+	return fmt.Errorf("matching_criteria must include a criteria for the \"type\" attribute, which selects the event type to deliver")
+}
@@ -0,0 +1,2106 @@
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type ResourceDiffMock struct {
+	ID     string
+	Before map[string]interface{}
+	After  map[string]interface{}
+}
+
+func (d *ResourceDiffMock) HasChange(key string) bool {
+	old, new := d.GetChange(key)
+	return !reflect.DeepEqual(old, new)
+}
+
+func (d *ResourceDiffMock) GetChange(key string) (interface{}, interface{}) {
+	return d.Before[key], d.After[key]
+}
+
+func (d *ResourceDiffMock) Get(key string) interface{} {
+	return d.After[key]
+}
+
+func (d *ResourceDiffMock) GetOk(key string) (interface{}, bool) {
+	v, ok := d.After[key]
+	return v, ok
+}
+
+func (d *ResourceDiffMock) Id() string {
+	return d.ID
+}
+
+func TestLoggingBucketLockDiff(t *testing.T) {
+	cases := map[string]struct {
+		Before, After map[string]interface{}
+		ExpectError   bool
+	}{
+		"unlocked, decrease": {
+			Before: map[string]interface{}{"locked": false, "retention_days": 30},
+			After:  map[string]interface{}{"locked": false, "retention_days": 7},
+		},
+		"locked, increase": {
+			Before: map[string]interface{}{"locked": true, "retention_days": 30},
+			After:  map[string]interface{}{"locked": true, "retention_days": 60},
+		},
+		"locked, unchanged": {
+			Before: map[string]interface{}{"locked": true, "retention_days": 30},
+			After:  map[string]interface{}{"locked": true, "retention_days": 30},
+		},
+		"locking, decrease": {
+			Before: map[string]interface{}{"locked": false, "retention_days": 30},
+			After:  map[string]interface{}{"locked": true, "retention_days": 7},
+		},
+		"locked, decrease": {
+			Before:      map[string]interface{}{"locked": true, "retention_days": 30},
+			After:       map[string]interface{}{"locked": true, "retention_days": 7},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{Before: tc.Before, After: tc.After}
+		err := loggingBucketLockDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestIPWithinCIDRDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"in range": {
+			After: map[string]interface{}{"address": "10.0.0.5", "ip_cidr_range": "10.0.0.0/24"},
+		},
+		"unset cidr": {
+			After: map[string]interface{}{"address": "10.0.0.5"},
+		},
+		"unset ip": {
+			After: map[string]interface{}{"ip_cidr_range": "10.0.0.0/24"},
+		},
+		"out of range": {
+			After:       map[string]interface{}{"address": "10.0.1.5", "ip_cidr_range": "10.0.0.0/24"},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := ipWithinCIDRDiffFunc(d, "address", "ip_cidr_range")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestBigtableDevelopmentDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"production, two clusters": {
+			After: map[string]interface{}{
+				"instance_type": "PRODUCTION",
+				"cluster": []interface{}{
+					map[string]interface{}{"cluster_id": "a", "num_nodes": 3},
+					map[string]interface{}{"cluster_id": "b", "num_nodes": 3},
+				},
+			},
+		},
+		"production, num_nodes": {
+			After: map[string]interface{}{"instance_type": "PRODUCTION", "num_nodes": 3},
+		},
+		"development, one cluster": {
+			After: map[string]interface{}{
+				"instance_type": "DEVELOPMENT",
+				"cluster": []interface{}{
+					map[string]interface{}{"cluster_id": "a"},
+				},
+			},
+		},
+		"development, two clusters": {
+			After: map[string]interface{}{
+				"instance_type": "DEVELOPMENT",
+				"cluster": []interface{}{
+					map[string]interface{}{"cluster_id": "a"},
+					map[string]interface{}{"cluster_id": "b"},
+				},
+			},
+			ExpectError: true,
+		},
+		"development, cluster num_nodes": {
+			After: map[string]interface{}{
+				"instance_type": "DEVELOPMENT",
+				"cluster": []interface{}{
+					map[string]interface{}{"cluster_id": "a", "num_nodes": 1},
+				},
+			},
+			ExpectError: true,
+		},
+		"development, num_nodes": {
+			After:       map[string]interface{}{"instance_type": "DEVELOPMENT", "num_nodes": 1},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := bigtableDevelopmentDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestMinMaxReplicasDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"min less than max": {
+			After: map[string]interface{}{"min_replicas": 1, "max_replicas": 5},
+		},
+		"min equal to max": {
+			After: map[string]interface{}{"min_replicas": 3, "max_replicas": 3},
+		},
+		"max unset": {
+			After: map[string]interface{}{"min_replicas": 3},
+		},
+		"min greater than max": {
+			After:       map[string]interface{}{"min_replicas": 5, "max_replicas": 1},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := minMaxReplicasDiffFunc(d, "min_replicas", "max_replicas")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestDnsVisibilityDiff(t *testing.T) {
+	networks := []interface{}{
+		map[string]interface{}{
+			"networks": []interface{}{
+				map[string]interface{}{"network_url": "projects/my-project/global/networks/default"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"public without networks": {
+			After: map[string]interface{}{"visibility": "public"},
+		},
+		"private with networks": {
+			After: map[string]interface{}{"visibility": "private", "private_visibility_config": networks},
+		},
+		"public with networks": {
+			After:       map[string]interface{}{"visibility": "public", "private_visibility_config": networks},
+			ExpectError: true,
+		},
+		"default visibility with networks": {
+			After:       map[string]interface{}{"private_visibility_config": networks},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := dnsVisibilityDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestNotificationChannelLabelsDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"email with address": {
+			After: map[string]interface{}{
+				"type":   "email",
+				"labels": map[string]interface{}{"email_address": "ops@example.com"},
+			},
+		},
+		"unknown type": {
+			After: map[string]interface{}{"type": "carrier_pigeon"},
+		},
+		"email missing address": {
+			After: map[string]interface{}{
+				"type":   "email",
+				"labels": map[string]interface{}{"number": "+15555555555"},
+			},
+			ExpectError: true,
+		},
+		"email without labels": {
+			After:       map[string]interface{}{"type": "email"},
+			ExpectError: true,
+		},
+		"basic auth webhook missing username": {
+			After: map[string]interface{}{
+				"type":   "webhook_basicauth",
+				"labels": map[string]interface{}{"url": "https://example.com/hook"},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := notificationChannelLabelsDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestFilestoreCapacityDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"basic hdd at minimum": {
+			After: map[string]interface{}{
+				"tier":        "BASIC_HDD",
+				"file_shares": []interface{}{map[string]interface{}{"name": "share", "capacity_gb": 1024}},
+			},
+		},
+		"enterprise": {
+			After: map[string]interface{}{
+				"tier":        "ENTERPRISE",
+				"file_shares": []interface{}{map[string]interface{}{"name": "share", "capacity_gb": 2048}},
+			},
+		},
+		"unknown tier": {
+			After: map[string]interface{}{
+				"tier":        "FUTURE_TIER",
+				"file_shares": []interface{}{map[string]interface{}{"name": "share", "capacity_gb": 1}},
+			},
+		},
+		"basic hdd too small": {
+			After: map[string]interface{}{
+				"tier":        "BASIC_HDD",
+				"file_shares": []interface{}{map[string]interface{}{"name": "share", "capacity_gb": 512}},
+			},
+			ExpectError: true,
+		},
+		"basic ssd too small": {
+			After: map[string]interface{}{
+				"tier":        "BASIC_SSD",
+				"file_shares": []interface{}{map[string]interface{}{"name": "share", "capacity_gb": 1024}},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := filestoreCapacityDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestMaintenanceWindowDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		AllowWrap   bool
+		ExpectError bool
+	}{
+		"ordered": {
+			After: map[string]interface{}{"start_time": "01:00", "end_time": "05:00"},
+		},
+		"ordered, wrap allowed": {
+			After:     map[string]interface{}{"start_time": "01:00", "end_time": "05:00"},
+			AllowWrap: true,
+		},
+		"start after end, wrap allowed": {
+			After:     map[string]interface{}{"start_time": "22:00", "end_time": "02:00"},
+			AllowWrap: true,
+		},
+		"equal, wrap allowed": {
+			After:     map[string]interface{}{"start_time": "03:00", "end_time": "03:00"},
+			AllowWrap: true,
+		},
+		"end unset": {
+			After: map[string]interface{}{"start_time": "03:00"},
+		},
+		"start after end": {
+			After:       map[string]interface{}{"start_time": "22:00", "end_time": "02:00"},
+			ExpectError: true,
+		},
+		"equal": {
+			After:       map[string]interface{}{"start_time": "03:00", "end_time": "03:00"},
+			ExpectError: true,
+		},
+		"invalid time": {
+			After:       map[string]interface{}{"start_time": "3:00", "end_time": "05:00"},
+			AllowWrap:   true,
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := maintenanceWindowDiffFunc(d, "start_time", "end_time", tc.AllowWrap)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestSqlFlagValueDiff(t *testing.T) {
+	cases := map[string]struct {
+		Flags       []interface{}
+		ExpectError bool
+	}{
+		"boolean flag on": {
+			Flags: []interface{}{
+				map[string]interface{}{"name": "slow_query_log", "value": "on"},
+			},
+		},
+		"unknown flag": {
+			Flags: []interface{}{
+				map[string]interface{}{"name": "max_connections", "value": "100"},
+			},
+		},
+		"boolean flag yes": {
+			Flags: []interface{}{
+				map[string]interface{}{"name": "max_connections", "value": "100"},
+				map[string]interface{}{"name": "slow_query_log", "value": "yes"},
+			},
+			ExpectError: true,
+		},
+		"namespaced boolean flag true": {
+			Flags: []interface{}{
+				map[string]interface{}{"name": "cloudsql.iam_authentication", "value": "true"},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: map[string]interface{}{"settings.0.database_flags": tc.Flags}}
+		err := sqlFlagValueDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestGkeAddonConflictsDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"network policy only": {
+			After: map[string]interface{}{"network_policy.0.enabled": true, "datapath_provider": "LEGACY_DATAPATH"},
+		},
+		"dataplane v2 only": {
+			After: map[string]interface{}{"network_policy.0.enabled": false, "datapath_provider": "ADVANCED_DATAPATH"},
+		},
+		"neither": {
+			After: map[string]interface{}{},
+		},
+		"network policy with dataplane v2": {
+			After:       map[string]interface{}{"network_policy.0.enabled": true, "datapath_provider": "ADVANCED_DATAPATH"},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := gkeAddonConflictsDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestNetworkSubnetModeDiff(t *testing.T) {
+	cases := map[string]struct {
+		After         map[string]interface{}
+		ExpectWarning bool
+	}{
+		"auto subnet mode": {
+			After: map[string]interface{}{"auto_create_subnetworks": true},
+		},
+		"custom subnet mode": {
+			After: map[string]interface{}{"auto_create_subnetworks": false},
+		},
+		"legacy": {
+			After: map[string]interface{}{"auto_create_subnetworks": false, "ipv4_range": "10.0.0.0/16"},
+		},
+		"auto subnet mode with ipv4_range": {
+			After:         map[string]interface{}{"auto_create_subnetworks": true, "ipv4_range": "10.0.0.0/16"},
+			ExpectWarning: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		warning := networkSubnetModeDiffFunc(d)
+		if tc.ExpectWarning != (warning != "") {
+			t.Errorf("bad: %s, expected warning %t, got %q", tn, tc.ExpectWarning, warning)
+		}
+	}
+}
+
+func TestNodePoolLocationsDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"zones in region": {
+			After: map[string]interface{}{
+				"location":       "us-central1",
+				"node_locations": []interface{}{"us-central1-a", "us-central1-b"},
+			},
+		},
+		"zonal cluster": {
+			After: map[string]interface{}{
+				"location":       "us-central1-a",
+				"node_locations": []interface{}{"us-central1-b"},
+			},
+		},
+		"set of zones": {
+			After: map[string]interface{}{
+				"location":       "us-central1",
+				"node_locations": schema.NewSet(schema.HashString, []interface{}{"us-central1-f"}),
+			},
+		},
+		"no locations": {
+			After: map[string]interface{}{"location": "us-central1"},
+		},
+		"zone in another region": {
+			After: map[string]interface{}{
+				"location":       "us-central1",
+				"node_locations": []interface{}{"us-central1-a", "us-east1-b"},
+			},
+			ExpectError: true,
+		},
+		"malformed zone": {
+			After: map[string]interface{}{
+				"location":       "us-central1",
+				"node_locations": []interface{}{"us-central1"},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := nodePoolLocationsDiffFunc(d, "location", "node_locations")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestHealthCheckTimingDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"defaults": {
+			After: map[string]interface{}{
+				"check_interval_sec":  5,
+				"timeout_sec":         5,
+				"healthy_threshold":   2,
+				"unhealthy_threshold": 2,
+			},
+		},
+		"timeout less than interval": {
+			After: map[string]interface{}{"check_interval_sec": 10, "timeout_sec": 3},
+		},
+		"timeout greater than interval": {
+			After:       map[string]interface{}{"check_interval_sec": 5, "timeout_sec": 10},
+			ExpectError: true,
+		},
+		"zero healthy threshold": {
+			After: map[string]interface{}{
+				"check_interval_sec": 5,
+				"timeout_sec":        5,
+				"healthy_threshold":  0,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := healthCheckTimingDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestSslPolicyCustomFeaturesDiff(t *testing.T) {
+	features := schema.NewSet(schema.HashString, []interface{}{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"modern without features": {
+			After: map[string]interface{}{"profile": "MODERN"},
+		},
+		"custom with features": {
+			After: map[string]interface{}{"profile": "CUSTOM", "custom_features": features},
+		},
+		"modern with features": {
+			After:       map[string]interface{}{"profile": "MODERN", "custom_features": features},
+			ExpectError: true,
+		},
+		"custom without features": {
+			After:       map[string]interface{}{"profile": "CUSTOM", "custom_features": schema.NewSet(schema.HashString, nil)},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := sslPolicyCustomFeaturesDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestInstanceSchedulePolicyDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"schedule and time zone": {
+			After: map[string]interface{}{
+				"instance_schedule_policy.0.vm_start_schedule.0.schedule": "0 9 * * MON-FRI",
+				"instance_schedule_policy.0.vm_stop_schedule.0.schedule":  "0 17 * * MON-FRI",
+				"instance_schedule_policy.0.time_zone":                    "UTC",
+			},
+		},
+		"no policy": {
+			After: map[string]interface{}{},
+		},
+		"schedule without time zone": {
+			After: map[string]interface{}{
+				"instance_schedule_policy.0.vm_start_schedule.0.schedule": "0 9 * * *",
+			},
+			ExpectError: true,
+		},
+		"time zone without schedule": {
+			After: map[string]interface{}{
+				"instance_schedule_policy.0.time_zone": "UTC",
+			},
+			ExpectError: true,
+		},
+		"invalid schedule": {
+			After: map[string]interface{}{
+				"instance_schedule_policy.0.vm_stop_schedule.0.schedule": "every day at 5",
+				"instance_schedule_policy.0.time_zone":                   "UTC",
+			},
+			ExpectError: true,
+		},
+		"invalid time zone": {
+			After: map[string]interface{}{
+				"instance_schedule_policy.0.vm_start_schedule.0.schedule": "0 9 * * *",
+				"instance_schedule_policy.0.time_zone":                    "America/New York",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := instanceSchedulePolicyDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestWeightedBackendSumDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"sums to 100": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 70},
+					map[string]interface{}{"percent": 30},
+				},
+			},
+		},
+		"all zero": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 0},
+					map[string]interface{}{"percent": 0},
+				},
+			},
+		},
+		"no backends": {
+			After: map[string]interface{}{},
+		},
+		"sums to 90": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 60},
+					map[string]interface{}{"percent": 30},
+				},
+			},
+			ExpectError: true,
+		},
+		"sums to 110": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 100},
+					map[string]interface{}{"percent": 10},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := weightedBackendSumDiffFunc(d, "traffic", "percent")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestAutopilotConflictsDiff(t *testing.T) {
+	nodeConfig := []interface{}{map[string]interface{}{"machine_type": "e2-medium"}}
+	nodePool := []interface{}{map[string]interface{}{"name": "default-pool"}}
+
+	cases := map[string]struct {
+		Before, After map[string]interface{}
+		ExpectError   bool
+	}{
+		"autopilot": {
+			After: map[string]interface{}{
+				"enable_autopilot": true,
+			},
+		},
+		"standard with node config": {
+			After: map[string]interface{}{
+				"enable_autopilot": false,
+				"node_config":      []interface{}{map[string]interface{}{"machine_type": "n1-standard-1"}},
+			},
+		},
+		"autopilot with node config": {
+			After: map[string]interface{}{
+				"enable_autopilot": true,
+				"node_config":      []interface{}{map[string]interface{}{"machine_type": "n1-standard-1"}},
+			},
+			ExpectError: true,
+		},
+		"autopilot with node pool": {
+			After: map[string]interface{}{
+				"enable_autopilot": true,
+				"node_pool":        []interface{}{map[string]interface{}{"name": "default-pool"}},
+			},
+			ExpectError: true,
+		},
+		"existing autopilot, nodes read from API": {
+			Before: map[string]interface{}{
+				"enable_autopilot": true,
+				"node_config":      nodeConfig,
+				"node_pool":        nodePool,
+			},
+			After: map[string]interface{}{
+				"enable_autopilot": true,
+				"node_config":      nodeConfig,
+				"node_pool":        nodePool,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{Before: tc.Before, After: tc.After}
+		err := autopilotConflictsDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestBucketLifecycleRuleDiff(t *testing.T) {
+	rule := func(condition map[string]interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"action":    []interface{}{map[string]interface{}{"type": "Delete"}},
+				"condition": []interface{}{condition},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"age": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{"age": 30}),
+			},
+		},
+		"age zero": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{"age": 0}),
+			},
+		},
+		"created before": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{"created_before": "2019-01-31"}),
+			},
+		},
+		"no rules": {
+			After: map[string]interface{}{},
+		},
+		"no conditions": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{}),
+			},
+			ExpectError: true,
+		},
+		"negative age": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{"age": -1}),
+			},
+			ExpectError: true,
+		},
+		"created before timestamp": {
+			After: map[string]interface{}{
+				"lifecycle_rule": rule(map[string]interface{}{"created_before": "2019-01-31T00:00:00Z"}),
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := bucketLifecycleRuleDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestNegPortDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"vm ip port with port": {
+			After: map[string]interface{}{
+				"network_endpoint_type": "GCE_VM_IP_PORT",
+				"default_port":          8080,
+			},
+		},
+		"serverless without port": {
+			After: map[string]interface{}{
+				"network_endpoint_type": "SERVERLESS",
+			},
+		},
+		"serverless with port": {
+			After: map[string]interface{}{
+				"network_endpoint_type": "SERVERLESS",
+				"default_port":          8080,
+			},
+			ExpectError: true,
+		},
+		"vm ip with port": {
+			After: map[string]interface{}{
+				"network_endpoint_type": "GCE_VM_IP",
+				"default_port":          80,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := negPortDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestPushOIDCDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"audience and service account": {
+			After: map[string]interface{}{
+				"push_config.0.oidc_token.0.audience":              "https://example.com",
+				"push_config.0.oidc_token.0.service_account_email": "push@my-project.iam.gserviceaccount.com",
+			},
+		},
+		"service account only": {
+			After: map[string]interface{}{
+				"push_config.0.oidc_token.0.service_account_email": "push@my-project.iam.gserviceaccount.com",
+			},
+		},
+		"no token": {
+			After: map[string]interface{}{},
+		},
+		"audience without service account": {
+			After: map[string]interface{}{
+				"push_config.0.oidc_token.0.audience": "https://example.com",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := pushOIDCDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestReservationShareDiff(t *testing.T) {
+	projects := schema.NewSet(func(v interface{}) int {
+		return schema.HashString(v.(map[string]interface{})["id"])
+	}, []interface{}{
+		map[string]interface{}{"id": "other-project"},
+	})
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"specific projects with projects": {
+			After: map[string]interface{}{
+				"share_settings.0.share_type":  "SPECIFIC_PROJECTS",
+				"share_settings.0.project_map": projects,
+			},
+		},
+		"local without projects": {
+			After: map[string]interface{}{
+				"share_settings.0.share_type": "LOCAL",
+			},
+		},
+		"local with projects": {
+			After: map[string]interface{}{
+				"share_settings.0.share_type":  "LOCAL",
+				"share_settings.0.project_map": projects,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := reservationShareDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestCloudRunTrafficDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"latest revision": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 100, "latest_revision": true},
+				},
+			},
+		},
+		"latest and named revisions": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 90, "revision_name": "my-service-00001"},
+					map[string]interface{}{"percent": 10, "latest_revision": true},
+				},
+			},
+		},
+		"no traffic": {
+			After: map[string]interface{}{},
+		},
+		"both set": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 100, "latest_revision": true, "revision_name": "my-service-00001"},
+				},
+			},
+			ExpectError: true,
+		},
+		"neither set": {
+			After: map[string]interface{}{
+				"traffic": []interface{}{
+					map[string]interface{}{"percent": 90, "latest_revision": true},
+					map[string]interface{}{"percent": 10},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := cloudRunTrafficDiffFunc(d, "traffic")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestSqlBackupConfigDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"binary log on mysql": {
+			After: map[string]interface{}{
+				"database_version": "MYSQL_5_7",
+				"settings.0.backup_configuration.0.binary_log_enabled": true,
+			},
+		},
+		"point in time recovery on postgres": {
+			After: map[string]interface{}{
+				"database_version": "POSTGRES_9_6",
+				"settings.0.backup_configuration.0.point_in_time_recovery_enabled": true,
+			},
+		},
+		"binary log disabled on postgres": {
+			After: map[string]interface{}{
+				"database_version": "POSTGRES_9_6",
+				"settings.0.backup_configuration.0.binary_log_enabled": false,
+			},
+		},
+		"binary log on postgres": {
+			After: map[string]interface{}{
+				"database_version": "POSTGRES_9_6",
+				"settings.0.backup_configuration.0.binary_log_enabled": true,
+			},
+			ExpectError: true,
+		},
+		"point in time recovery on mysql": {
+			After: map[string]interface{}{
+				"database_version": "MYSQL_5_6",
+				"settings.0.backup_configuration.0.point_in_time_recovery_enabled": true,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := sqlBackupConfigDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestDataprocSingleNodeDiff(t *testing.T) {
+	singleNode := map[string]interface{}{"dataproc:dataproc.allow.zero.workers": "true"}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"single node": {
+			After: map[string]interface{}{
+				"cluster_config.0.software_config.0.override_properties": singleNode,
+				"cluster_config.0.worker_config.0.num_instances":         0,
+			},
+		},
+		"standard with workers": {
+			After: map[string]interface{}{
+				"cluster_config.0.worker_config.0.num_instances": 2,
+			},
+		},
+		"single node with workers": {
+			After: map[string]interface{}{
+				"cluster_config.0.software_config.0.override_properties": singleNode,
+				"cluster_config.0.worker_config.0.num_instances":         2,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := dataprocSingleNodeDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestRequiresStopForUpdateDiff(t *testing.T) {
+	cases := map[string]struct {
+		ID            string
+		Before, After map[string]interface{}
+		ExpectError   bool
+	}{
+		"machine type change allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"machine_type": "n1-standard-1",
+			},
+			After: map[string]interface{}{
+				"machine_type":              "n1-standard-2",
+				"allow_stopping_for_update": true,
+			},
+		},
+		"no change": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"machine_type": "n1-standard-1",
+			},
+			After: map[string]interface{}{
+				"machine_type": "n1-standard-1",
+			},
+		},
+		"create": {
+			Before: map[string]interface{}{},
+			After: map[string]interface{}{
+				"machine_type":     "n1-standard-1",
+				"min_cpu_platform": "Intel Skylake",
+				"enable_display":   true,
+			},
+		},
+		"create with bool set to false": {
+			Before: map[string]interface{}{
+				"enable_display": false,
+			},
+			After: map[string]interface{}{
+				"enable_display": true,
+			},
+		},
+		"machine type change not allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"machine_type": "n1-standard-1",
+			},
+			After: map[string]interface{}{
+				"machine_type":              "n1-standard-2",
+				"allow_stopping_for_update": false,
+			},
+			ExpectError: true,
+		},
+		"service account change not allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"machine_type":    "n1-standard-1",
+				"service_account": []interface{}{map[string]interface{}{"email": "a@my-project.iam.gserviceaccount.com"}},
+			},
+			After: map[string]interface{}{
+				"machine_type":    "n1-standard-1",
+				"service_account": []interface{}{map[string]interface{}{"email": "b@my-project.iam.gserviceaccount.com"}},
+			},
+			ExpectError: true,
+		},
+		"bool change not allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"enable_display": false,
+			},
+			After: map[string]interface{}{
+				"enable_display": true,
+			},
+			ExpectError: true,
+		},
+		"first set on update not allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"min_cpu_platform": "",
+			},
+			After: map[string]interface{}{
+				"min_cpu_platform": "Intel Skylake",
+			},
+			ExpectError: true,
+		},
+		"service account added on update not allowed": {
+			ID: "my-instance",
+			Before: map[string]interface{}{
+				"service_account": []interface{}{},
+			},
+			After: map[string]interface{}{
+				"service_account": []interface{}{map[string]interface{}{"email": "a@my-project.iam.gserviceaccount.com"}},
+			},
+			ExpectError: true,
+		},
+	}
+
+	fields := []string{"machine_type", "min_cpu_platform", "service_account", "enable_display"}
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{ID: tc.ID, Before: tc.Before, After: tc.After}
+		err := requiresStopForUpdateDiffFunc(d, fields, "allow_stopping_for_update")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestMigTargetSizeAutoscalerDiff(t *testing.T) {
+	cases := map[string]struct {
+		Before, After map[string]interface{}
+		ExpectWarning bool
+	}{
+		"not autoscaled, create": {
+			Before: map[string]interface{}{},
+			After: map[string]interface{}{
+				"target_size": 3,
+			},
+		},
+		"not autoscaled, resized": {
+			Before: map[string]interface{}{
+				"target_size": 5,
+			},
+			After: map[string]interface{}{
+				"target_size": 3,
+			},
+		},
+		"autoscaled, unset": {
+			Before: map[string]interface{}{},
+			After: map[string]interface{}{
+				"autoscaled": true,
+			},
+		},
+		"autoscaled, unchanged": {
+			Before: map[string]interface{}{
+				"autoscaled":  true,
+				"target_size": 3,
+			},
+			After: map[string]interface{}{
+				"autoscaled":  true,
+				"target_size": 3,
+			},
+		},
+		"autoscaled, create": {
+			Before: map[string]interface{}{},
+			After: map[string]interface{}{
+				"autoscaled":  true,
+				"target_size": 3,
+			},
+			ExpectWarning: true,
+		},
+		"autoscaled, resized": {
+			Before: map[string]interface{}{
+				"autoscaled":  true,
+				"target_size": 5,
+			},
+			After: map[string]interface{}{
+				"autoscaled":  true,
+				"target_size": 3,
+			},
+			ExpectWarning: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{Before: tc.Before, After: tc.After}
+		warning := migTargetSizeAutoscalerDiffFunc(d, "autoscaled")
+		if tc.ExpectWarning != (warning != "") {
+			t.Errorf("bad: %s, expected warning %t, got %q", tn, tc.ExpectWarning, warning)
+		}
+	}
+}
+
+func TestSqlReplicaDiff(t *testing.T) {
+	replicaConfig := []interface{}{map[string]interface{}{"failover_target": true}}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"replica": {
+			After: map[string]interface{}{
+				"master_instance_name":  "my-master",
+				"replica_configuration": replicaConfig,
+			},
+		},
+		"replica without configuration": {
+			After: map[string]interface{}{
+				"master_instance_name": "my-master",
+			},
+		},
+		"master": {
+			After: map[string]interface{}{},
+		},
+		"configuration without master": {
+			After: map[string]interface{}{
+				"replica_configuration": replicaConfig,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := sqlReplicaDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestFirewallLogConfigDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"metadata with logging": {
+			After: map[string]interface{}{
+				"enable_logging":        true,
+				"log_config.0.metadata": "INCLUDE_ALL_METADATA",
+			},
+		},
+		"logging without metadata": {
+			After: map[string]interface{}{
+				"enable_logging": true,
+			},
+		},
+		"neither": {
+			After: map[string]interface{}{},
+		},
+		"metadata without logging": {
+			After: map[string]interface{}{
+				"enable_logging":        false,
+				"log_config.0.metadata": "EXCLUDE_ALL_METADATA",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := firewallLogConfigDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestNatSubnetworkDiff(t *testing.T) {
+	subnetworks := []interface{}{
+		map[string]interface{}{
+			"name":                    "my-subnetwork",
+			"source_ip_ranges_to_nat": []interface{}{"ALL_IP_RANGES"},
+		},
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"list with subnetworks": {
+			After: map[string]interface{}{
+				"source_subnetwork_ip_ranges_to_nat": "LIST_OF_SUBNETWORKS",
+				"subnetwork":                         subnetworks,
+			},
+		},
+		"all without subnetworks": {
+			After: map[string]interface{}{
+				"source_subnetwork_ip_ranges_to_nat": "ALL_SUBNETWORKS_ALL_IP_RANGES",
+			},
+		},
+		"all with subnetworks": {
+			After: map[string]interface{}{
+				"source_subnetwork_ip_ranges_to_nat": "ALL_SUBNETWORKS_ALL_IP_RANGES",
+				"subnetwork":                         subnetworks,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := natSubnetworkDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestCloudRunScalingDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"min below max": {
+			After: map[string]interface{}{
+				"template.0.scaling.0.min_instance_count": 1,
+				"template.0.scaling.0.max_instance_count": 10,
+			},
+		},
+		"min only": {
+			After: map[string]interface{}{
+				"template.0.scaling.0.min_instance_count": 1,
+			},
+		},
+		"min above max": {
+			After: map[string]interface{}{
+				"template.0.scaling.0.min_instance_count": 5,
+				"template.0.scaling.0.max_instance_count": 2,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := cloudRunScalingDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestReleaseChannelVersionDiff(t *testing.T) {
+	cases := map[string]struct {
+		After         map[string]interface{}
+		ExpectWarning bool
+	}{
+		"channel": {
+			After: map[string]interface{}{"release_channel.0.channel": "REGULAR"},
+		},
+		"version": {
+			After: map[string]interface{}{"min_master_version": "1.10.6-gke.2"},
+		},
+		"unspecified channel and version": {
+			After: map[string]interface{}{"release_channel.0.channel": "UNSPECIFIED", "min_master_version": "1.10.6-gke.2"},
+		},
+		"channel and version": {
+			After:         map[string]interface{}{"release_channel.0.channel": "STABLE", "min_master_version": "1.10.6-gke.2"},
+			ExpectWarning: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		warning := releaseChannelVersionDiffFunc(d)
+		if tc.ExpectWarning != (warning != "") {
+			t.Errorf("bad: %s, expected warning %t, got %q", tn, tc.ExpectWarning, warning)
+		}
+	}
+}
+
+func TestForwardingRuleIPVersionDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"ipv4": {
+			After: map[string]interface{}{"ip_version": "IPV4", "ip_address": "203.0.113.10"},
+		},
+		"ipv6": {
+			After: map[string]interface{}{"ip_version": "IPV6", "ip_address": "2001:db8::10"},
+		},
+		"no version": {
+			After: map[string]interface{}{"ip_address": "2001:db8::10"},
+		},
+		"address reference": {
+			After: map[string]interface{}{"ip_version": "IPV6", "ip_address": "projects/my-project/global/addresses/my-address"},
+		},
+		"ipv4 with ipv6 address": {
+			After:       map[string]interface{}{"ip_version": "IPV4", "ip_address": "2001:db8::10"},
+			ExpectError: true,
+		},
+		"ipv6 with ipv4 address": {
+			After:       map[string]interface{}{"ip_version": "IPV6", "ip_address": "203.0.113.10"},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := forwardingRuleIPVersionDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestInstanceIPForwardAliasDiff(t *testing.T) {
+	withAliases := []interface{}{
+		map[string]interface{}{
+			"network":        "default",
+			"alias_ip_range": []interface{}{map[string]interface{}{"ip_cidr_range": "/24"}},
+		},
+	}
+	withoutAliases := []interface{}{
+		map[string]interface{}{"network": "default"},
+	}
+
+	cases := map[string]struct {
+		After         map[string]interface{}
+		ExpectWarning bool
+	}{
+		"aliases with forwarding": {
+			After: map[string]interface{}{"can_ip_forward": true, "network_interface": withAliases},
+		},
+		"no aliases without forwarding": {
+			After: map[string]interface{}{"can_ip_forward": false, "network_interface": withoutAliases},
+		},
+		"aliases without forwarding": {
+			After:         map[string]interface{}{"can_ip_forward": false, "network_interface": withAliases},
+			ExpectWarning: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		warning := instanceIPForwardAliasDiffFunc(d)
+		if tc.ExpectWarning != (warning != "") {
+			t.Errorf("bad: %s, expected warning %t, got %q", tn, tc.ExpectWarning, warning)
+		}
+	}
+}
+
+func TestSqlDiskAutoresizeDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"limit above size": {
+			After: map[string]interface{}{
+				"settings.0.disk_autoresize":       true,
+				"settings.0.disk_autoresize_limit": 100,
+				"settings.0.disk_size":             50,
+			},
+		},
+		"unlimited": {
+			After: map[string]interface{}{
+				"settings.0.disk_autoresize":       true,
+				"settings.0.disk_autoresize_limit": 0,
+				"settings.0.disk_size":             50,
+			},
+		},
+		"autoresize disabled": {
+			After: map[string]interface{}{
+				"settings.0.disk_autoresize":       false,
+				"settings.0.disk_autoresize_limit": 20,
+				"settings.0.disk_size":             50,
+			},
+		},
+		"limit below size": {
+			After: map[string]interface{}{
+				"settings.0.disk_autoresize":       true,
+				"settings.0.disk_autoresize_limit": 20,
+				"settings.0.disk_size":             50,
+			},
+			ExpectError: true,
+		},
+		"limit below size with default autoresize": {
+			After: map[string]interface{}{
+				"settings.0.disk_autoresize_limit": 20,
+				"settings.0.disk_size":             50,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := sqlDiskAutoresizeDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestRouteNextHopDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"gateway": {
+			After: map[string]interface{}{"next_hop_gateway": "default-internet-gateway"},
+		},
+		"ip": {
+			After: map[string]interface{}{"next_hop_ip": "10.0.0.2"},
+		},
+		"none": {
+			After:       map[string]interface{}{},
+			ExpectError: true,
+		},
+		"gateway and ip": {
+			After: map[string]interface{}{
+				"next_hop_gateway": "default-internet-gateway",
+				"next_hop_ip":      "10.0.0.2",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := routeNextHopDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestDiskEncryptionKeyDiff(t *testing.T) {
+	kmsKey := "projects/my-project/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key"
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"raw key": {
+			After: map[string]interface{}{"disk_encryption_key_raw": "SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0="},
+		},
+		"kms key": {
+			After: map[string]interface{}{"kms_key_self_link": kmsKey},
+		},
+		"neither": {
+			After: map[string]interface{}{},
+		},
+		"both": {
+			After: map[string]interface{}{
+				"disk_encryption_key_raw": "SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0=",
+				"kms_key_self_link":       kmsKey,
+			},
+			ExpectError: true,
+		},
+		"malformed kms key": {
+			After:       map[string]interface{}{"kms_key_self_link": "my-ring/my-key"},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := diskEncryptionKeyDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestManagedCertDomainCountDiff(t *testing.T) {
+	domains := func(n int) []interface{} {
+		l := make([]interface{}, n)
+		for i := range l {
+			l[i] = fmt.Sprintf("site%d.example.com", i)
+		}
+		return l
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"one domain": {
+			After: map[string]interface{}{"managed.0.domains": domains(1)},
+		},
+		"maximum": {
+			After: map[string]interface{}{"managed.0.domains": domains(100)},
+		},
+		"not managed": {
+			After: map[string]interface{}{},
+		},
+		"over the maximum": {
+			After:       map[string]interface{}{"managed.0.domains": domains(101)},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := managedCertDomainCountDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestGpuMaintenanceDiff(t *testing.T) {
+	gpus := []interface{}{
+		map[string]interface{}{"count": 1, "type": "nvidia-tesla-k80"},
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"gpus with terminate": {
+			After: map[string]interface{}{
+				"guest_accelerator":                gpus,
+				"scheduling.0.on_host_maintenance": "TERMINATE",
+			},
+		},
+		"no gpus with migrate": {
+			After: map[string]interface{}{
+				"scheduling.0.on_host_maintenance": "MIGRATE",
+			},
+		},
+		"zero gpus with migrate": {
+			After: map[string]interface{}{
+				"guest_accelerator": []interface{}{
+					map[string]interface{}{"count": 0, "type": "nvidia-tesla-k80"},
+				},
+				"scheduling.0.on_host_maintenance": "MIGRATE",
+			},
+		},
+		"gpus with migrate": {
+			After: map[string]interface{}{
+				"guest_accelerator":                gpus,
+				"scheduling.0.on_host_maintenance": "MIGRATE",
+			},
+			ExpectError: true,
+		},
+		"gpus without maintenance policy": {
+			After: map[string]interface{}{
+				"guest_accelerator": gpus,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := gpuMaintenanceDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestSpotSchedulingDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"preemptible without automatic restart": {
+			After: map[string]interface{}{
+				"scheduling.0.preemptible":       true,
+				"scheduling.0.automatic_restart": false,
+			},
+		},
+		"standard with automatic restart": {
+			After: map[string]interface{}{
+				"scheduling.0.preemptible":       false,
+				"scheduling.0.automatic_restart": true,
+			},
+		},
+		"preemptible with automatic restart": {
+			After: map[string]interface{}{
+				"scheduling.0.preemptible":       true,
+				"scheduling.0.automatic_restart": true,
+			},
+			ExpectError: true,
+		},
+		"spot with automatic restart": {
+			After: map[string]interface{}{
+				"scheduling.0.provisioning_model": "SPOT",
+				"scheduling.0.automatic_restart":  true,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := spotSchedulingDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestCloudRunVolumeMountDiff(t *testing.T) {
+	containers := []interface{}{
+		map[string]interface{}{
+			"image": "gcr.io/cloudrun/hello",
+			"volume_mounts": []interface{}{
+				map[string]interface{}{"name": "secrets", "mount_path": "/secrets"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"declared volume": {
+			After: map[string]interface{}{
+				"template.0.volumes":    []interface{}{map[string]interface{}{"name": "secrets"}},
+				"template.0.containers": containers,
+			},
+		},
+		"no mounts": {
+			After: map[string]interface{}{
+				"template.0.volumes": []interface{}{map[string]interface{}{"name": "secrets"}},
+				"template.0.containers": []interface{}{
+					map[string]interface{}{"image": "gcr.io/cloudrun/hello"},
+				},
+			},
+		},
+		"undeclared volume": {
+			After: map[string]interface{}{
+				"template.0.volumes":    []interface{}{map[string]interface{}{"name": "cache"}},
+				"template.0.containers": containers,
+			},
+			ExpectError: true,
+		},
+		"no volumes": {
+			After: map[string]interface{}{
+				"template.0.containers": containers,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := cloudRunVolumeMountDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestHealthCheckTypeDiff(t *testing.T) {
+	check := []interface{}{map[string]interface{}{"port": 80}}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"http": {
+			After: map[string]interface{}{"http_health_check": check},
+		},
+		"grpc": {
+			After: map[string]interface{}{"grpc_health_check": check},
+		},
+		"none": {
+			After:       map[string]interface{}{},
+			ExpectError: true,
+		},
+		"http and tcp": {
+			After: map[string]interface{}{
+				"http_health_check": check,
+				"tcp_health_check":  check,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := healthCheckTypeDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestRouterAdvertiseDiff(t *testing.T) {
+	ranges := []interface{}{map[string]interface{}{"range": "10.0.0.0/16"}}
+
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"custom with ranges": {
+			After: map[string]interface{}{
+				"bgp.0.advertise_mode":       "CUSTOM",
+				"bgp.0.advertised_ip_ranges": ranges,
+			},
+		},
+		"custom with groups": {
+			After: map[string]interface{}{
+				"bgp.0.advertise_mode":    "CUSTOM",
+				"bgp.0.advertised_groups": []interface{}{"ALL_SUBNETS"},
+			},
+		},
+		"default without advertisements": {
+			After: map[string]interface{}{
+				"bgp.0.advertise_mode": "DEFAULT",
+			},
+		},
+		"default with ranges": {
+			After: map[string]interface{}{
+				"bgp.0.advertise_mode":       "DEFAULT",
+				"bgp.0.advertised_ip_ranges": ranges,
+			},
+			ExpectError: true,
+		},
+		"unset mode with groups": {
+			After: map[string]interface{}{
+				"bgp.0.advertised_groups": []interface{}{"ALL_SUBNETS"},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := routerAdvertiseDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestTemplateDiskSourceDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"image": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"source_image": "debian-cloud/debian-9", "boot": true},
+				},
+			},
+		},
+		"image and existing disk": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"source_image": "debian-cloud/debian-9", "boot": true},
+					map[string]interface{}{"source": "my-disk"},
+				},
+			},
+		},
+		"scratch": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"type": "SCRATCH", "disk_type": "local-ssd"},
+				},
+			},
+		},
+		"blank": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"type": "PERSISTENT", "disk_size_gb": 100},
+				},
+			},
+		},
+		"image and snapshot": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"source_image": "debian-cloud/debian-9", "source_snapshot": "my-snapshot"},
+				},
+			},
+			ExpectError: true,
+		},
+		"no source": {
+			After: map[string]interface{}{
+				"disk": []interface{}{
+					map[string]interface{}{"source_image": "debian-cloud/debian-9", "boot": true},
+					map[string]interface{}{"type": "PERSISTENT"},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := templateDiskSourceDiffFunc(d, "disk")
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestSchedulerRetryBackoffDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"min below max": {
+			After: map[string]interface{}{
+				"retry_config.0.min_backoff_duration": "5s",
+				"retry_config.0.max_backoff_duration": "1h",
+			},
+		},
+		"equal": {
+			After: map[string]interface{}{
+				"retry_config.0.min_backoff_duration": "60s",
+				"retry_config.0.max_backoff_duration": "1m",
+			},
+		},
+		"min only": {
+			After: map[string]interface{}{
+				"retry_config.0.min_backoff_duration": "5s",
+			},
+		},
+		"invalid duration": {
+			After: map[string]interface{}{
+				"retry_config.0.min_backoff_duration": "five seconds",
+				"retry_config.0.max_backoff_duration": "1s",
+			},
+		},
+		"min above max": {
+			After: map[string]interface{}{
+				"retry_config.0.min_backoff_duration": "10m",
+				"retry_config.0.max_backoff_duration": "60s",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := schedulerRetryBackoffDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestFirewallTargetDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"target tags": {
+			After: map[string]interface{}{
+				"target_tags": []interface{}{"web"},
+			},
+		},
+		"target service accounts": {
+			After: map[string]interface{}{
+				"target_service_accounts": []interface{}{"web@my-project.iam.gserviceaccount.com"},
+			},
+		},
+		"source tags and target service accounts": {
+			After: map[string]interface{}{
+				"source_tags":             []interface{}{"lb"},
+				"target_service_accounts": []interface{}{"web@my-project.iam.gserviceaccount.com"},
+			},
+		},
+		"both targets": {
+			After: map[string]interface{}{
+				"target_tags":             []interface{}{"web"},
+				"target_service_accounts": []interface{}{"web@my-project.iam.gserviceaccount.com"},
+			},
+			ExpectError: true,
+		},
+		"both sources": {
+			After: map[string]interface{}{
+				"source_tags":             []interface{}{"lb"},
+				"source_service_accounts": []interface{}{"lb@my-project.iam.gserviceaccount.com"},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := firewallTargetDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestGlobalAddressPeeringDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"peering range": {
+			After: map[string]interface{}{
+				"address_type":  "INTERNAL",
+				"purpose":       "VPC_PEERING",
+				"prefix_length": 16,
+			},
+		},
+		"external address": {
+			After: map[string]interface{}{},
+		},
+		"prefix length without purpose": {
+			After: map[string]interface{}{
+				"address_type":  "INTERNAL",
+				"prefix_length": 16,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := globalAddressPeeringDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
+
+func TestEventarcCriteriaDiff(t *testing.T) {
+	cases := map[string]struct {
+		After       map[string]interface{}
+		ExpectError bool
+	}{
+		"type and bucket": {
+			After: map[string]interface{}{
+				"matching_criteria": []interface{}{
+					map[string]interface{}{
+						"attribute": "type",
+						"value":     "google.cloud.storage.object.v1.finalized",
+					},
+					map[string]interface{}{
+						"attribute": "bucket",
+						"value":     "my-bucket",
+					},
+				},
+			},
+		},
+		"no criteria": {
+			After: map[string]interface{}{
+				"matching_criteria": []interface{}{},
+			},
+			ExpectError: true,
+		},
+		"missing type": {
+			After: map[string]interface{}{
+				"matching_criteria": []interface{}{
+					map[string]interface{}{
+						"attribute": "bucket",
+						"value":     "my-bucket",
+					},
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{After: tc.After}
+		err := eventarcCriteriaDiffFunc(d)
+		if tc.ExpectError != (err != nil) {
+			t.Errorf("bad: %s, expected error %t, got %v", tn, tc.ExpectError, err)
+		}
+	}
+}
@@ -23,8 +23,9 @@ func dataSourceGoogleContainerImage() *schema.Resource {
 				Optional: true,
 			},
 			"region": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateGCRLocation,
 			},
 			"project": {
 				Type:     schema.TypeString,
@@ -11,8 +11,9 @@ func dataSourceGoogleContainerRepo() *schema.Resource {
 		Read: containerRegistryRepoRead,
 		Schema: map[string]*schema.Schema{
 			"region": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateGCRLocation,
 			},
 			"project": {
 				Type:     schema.TypeString,
@@ -55,7 +55,7 @@ func resourceBigtableInstance() *schema.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				Default:      "PRODUCTION",
-				ValidateFunc: validation.StringInSlice([]string{"DEVELOPMENT", "PRODUCTION"}, false),
+				ValidateFunc: validateBigtableInstanceType,
 			},
 
 			"storage_type": {
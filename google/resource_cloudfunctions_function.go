@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +25,8 @@ var functionAllowedMemory = map[int]bool{
 	512:  true,
 	1024: true,
 	2048: true,
+	4096: true,
+	8192: true,
 }
 
 const functionDefaultAllowedMemoryMb = 256
@@ -64,13 +67,36 @@ func parseCloudFunctionId(id string, config *Config) (*cloudFunctionId, error) {
 }
 
 func joinMapKeys(mapToJoin *map[int]bool) string {
-	var keys []string
+	var ints []int
 	for key := range *mapToJoin {
+		ints = append(ints, key)
+	}
+	sort.Ints(ints)
+
+	var keys []string
+	for _, key := range ints {
 		keys = append(keys, strconv.Itoa(key))
 	}
 	return strings.Join(keys, ",")
 }
 
+// validateFunctionMemoryMB checks the memory of a 1st gen function against the
+// sizes in functionAllowedMemory. 2nd gen functions instead take a Kubernetes
+// style quantity string such as "512M", which this doesn't cover.
+func validateFunctionMemoryMB(v interface{}, k string) (ws []string, errors []error) {
+	availableMemoryMB, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+
+	if functionAllowedMemory[availableMemoryMB] != true {
+		errors = append(errors, fmt.Errorf("Allowed values for memory (in MB) are: %s . Got %d",
+			joinMapKeys(&functionAllowedMemory), availableMemoryMB))
+	}
+	return
+}
+
 func resourceCloudFunctionsFunction() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCloudFunctionsCreate,
@@ -134,18 +160,10 @@ func resourceCloudFunctionsFunction() *schema.Resource {
 			},
 
 			"available_memory_mb": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  functionDefaultAllowedMemoryMb,
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					availableMemoryMB := v.(int)
-
-					if functionAllowedMemory[availableMemoryMB] != true {
-						errors = append(errors, fmt.Errorf("Allowed values for memory (in MB) are: %s . Got %d",
-							joinMapKeys(&functionAllowedMemory), availableMemoryMB))
-					}
-					return
-				},
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      functionDefaultAllowedMemoryMb,
+				ValidateFunc: validateFunctionMemoryMB,
 			},
 
 			"timeout": {
@@ -5,7 +5,6 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
 
 	"google.golang.org/api/compute/v1"
 )
@@ -30,7 +29,7 @@ func resourceComputeGlobalAddress() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"IPV4", "IPV6", ""}, false),
+				ValidateFunc: validateIPVersion,
 			},
 
 			"project": &schema.Schema{
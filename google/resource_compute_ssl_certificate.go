@@ -37,19 +37,10 @@ func resourceComputeSslCertificate() *schema.Resource {
 			},
 
 			"name_prefix": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					// https://cloud.google.com/compute/docs/reference/latest/sslCertificates#resource
-					// uuid is 26 characters, limit the prefix to 37.
-					value := v.(string)
-					if len(value) > 37 {
-						errors = append(errors, fmt.Errorf(
-							"%q cannot be longer than 37 characters, name is limited to 63", k))
-					}
-					return
-				},
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateNamePrefix(37),
 			},
 
 			"private_key": &schema.Schema{
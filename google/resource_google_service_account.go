@@ -36,7 +36,7 @@ func resourceGoogleServiceAccount() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validateRFC1035Name(6, 30),
+				ValidateFunc: validateServiceAccountAccountID,
 			},
 			"display_name": &schema.Schema{
 				Type:     schema.TypeString,
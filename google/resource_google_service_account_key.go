@@ -28,7 +28,7 @@ func resourceGoogleServiceAccountKey() *schema.Resource {
 				Default:      "KEY_ALG_RSA_2048",
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"KEY_ALG_UNSPECIFIED", "KEY_ALG_RSA_1024", "KEY_ALG_RSA_2048"}, false),
+				ValidateFunc: validateSAKeyAlgorithm,
 			},
 			"pgp_key": {
 				Type:     schema.TypeString,
@@ -40,7 +40,7 @@ func resourceGoogleServiceAccountKey() *schema.Resource {
 				Default:      "TYPE_GOOGLE_CREDENTIALS_FILE",
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"TYPE_UNSPECIFIED", "TYPE_PKCS12_FILE", "TYPE_GOOGLE_CREDENTIALS_FILE"}, false),
+				ValidateFunc: validateSAKeyType,
 			},
 			"public_key_type": &schema.Schema{
 				Type:         schema.TypeString,
@@ -1,13 +1,19 @@
 package google
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"math"
 	"net"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -16,10 +22,33 @@ const (
 	RegionRegex     = "[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?"
 	SubnetworkRegex = "[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?"
 
+	ComputeSelfLinkRegex = "^https://www\\.googleapis\\.com/compute/(?:v1|beta|alpha)/projects/[^/]+/.*[^/]$"
+
+	// Matches the shape of a concrete region name, such as "us-central1".
+	RegionNameRegex = "^[a-z]+(?:-[a-z]+)+[0-9]+$"
+	ZoneNameRegex   = "^[a-z]+(?:-[a-z]+)+[0-9]+-[a-z]$"
+
 	SubnetworkLinkRegex = "projects/(" + ProjectRegex + ")/regions/(" + RegionRegex + ")/subnetworks/(" + SubnetworkRegex + ")$"
 
 	RFC1035NameTemplate = "[a-z](?:[-a-z0-9]{%d,%d}[a-z0-9])"
 	CloudIoTIdRegex     = "^[a-zA-Z][-a-zA-Z0-9._+~%]{2,254}$"
+
+	SQLCustomTierRegex   = "^db-custom-([0-9]+)-([0-9]+)$"
+	SQLStandardTierRegex = "^db-(?:[a-z][a-z0-9]*-(?:standard|highmem|highcpu)-[0-9]+|f1-micro|g1-small)$"
+	SQLLegacyTierRegex   = "^D(?:0|1|2|4|8|16|32)$"
+
+	WorkloadIdentityIdRegex = "^[a-z0-9-]{4,32}$"
+
+	ComposerEnvironmentNameRegex = "^[a-z](?:[-0-9a-z]{0,62}[0-9a-z])?$"
+	ComposerImageVersionRegex    = "^composer-(?:[0-9]+(?:\\.[0-9]+\\.[0-9]+(?:-preview\\.[0-9]+)?)?|latest)-airflow-[0-9]+(?:\\.[0-9]+(?:\\.[0-9]+)?)?$"
+	ComposerImageVersionTemplate = "composer-<version>-airflow-<version>"
+
+	// Postgres extension flags are namespaced with a dot, as in
+	// "cloudsql.iam_authentication".
+	SQLFlagNameRegex = "^[a-z][a-z0-9_.]*$"
+
+	// https://cloud.google.com/storage/docs/naming#requirements
+	GCSBucketNameRegex = "^[a-z0-9](?:[-_.a-z0-9]*[a-z0-9])?$"
 )
 
 var (
@@ -32,6 +61,100 @@ var (
 	ServiceAccountLinkRegex = "projects/" + ProjectRegex + "/serviceAccounts/" + ServiceAccountNameRegex + "@" + ProjectRegex + "\\.iam\\.gserviceaccount\\.com$"
 )
 
+var ipProtocols = []string{"TCP", "UDP", "ICMP", "ESP", "AH", "SCTP", "IPIP", "ALL"}
+
+var zonalDiskTypes = []string{
+	"pd-standard",
+	"pd-ssd",
+	"pd-balanced",
+	"pd-extreme",
+	"hyperdisk-balanced",
+	"hyperdisk-extreme",
+	"hyperdisk-throughput",
+}
+
+var regionalDiskTypes = []string{
+	"pd-standard",
+	"pd-ssd",
+	"pd-balanced",
+	"hyperdisk-balanced-high-availability",
+}
+
+var serviceAccountKeyAlgorithms = []string{"KEY_ALG_UNSPECIFIED", "KEY_ALG_RSA_1024", "KEY_ALG_RSA_2048"}
+
+var serviceAccountPrivateKeyTypes = []string{"TYPE_UNSPECIFIED", "TYPE_PKCS12_FILE", "TYPE_GOOGLE_CREDENTIALS_FILE"}
+
+// channelRequiredLabels lists the labels that each type of Stackdriver
+// notification channel must set.
+var channelRequiredLabels = map[string][]string{
+	"email":             {"email_address"},
+	"sms":               {"number"},
+	"slack":             {"channel_name"},
+	"pagerduty":         {"service_key"},
+	"webhook_tokenauth": {"url"},
+	"webhook_basicauth": {"url", "username"},
+	"pubsub":            {"topic"},
+}
+
+// filestoreTierMinCapacityGb is the smallest file share, in GB, that each
+// Filestore tier supports.
+var filestoreTierMinCapacityGb = map[string]int{
+	"STANDARD":       1024,
+	"PREMIUM":        2560,
+	"BASIC_HDD":      1024,
+	"BASIC_SSD":      2560,
+	"HIGH_SCALE_SSD": 10240,
+	"ENTERPRISE":     1024,
+}
+
+// sqlBooleanFlags is a subset of the Cloud SQL database flags that take an
+// on/off value. It isn't exhaustive, so flags missing from it aren't checked.
+var sqlBooleanFlags = map[string]bool{
+	// MySQL
+	"general_log":                     true,
+	"local_infile":                    true,
+	"log_bin_trust_function_creators": true,
+	"log_queries_not_using_indexes":   true,
+	"skip_show_database":              true,
+	"slow_query_log":                  true,
+
+	// PostgreSQL
+	"cloudsql.enable_pgaudit":     true,
+	"cloudsql.iam_authentication": true,
+	"log_checkpoints":             true,
+	"log_connections":             true,
+	"log_disconnections":          true,
+	"log_hostname":                true,
+	"log_lock_waits":              true,
+}
+
+var monitoringComparisons = []string{
+	"COMPARISON_GT",
+	"COMPARISON_GE",
+	"COMPARISON_LT",
+	"COMPARISON_LE",
+	"COMPARISON_EQ",
+	"COMPARISON_NE",
+}
+
+var relativeNamePlaceholderRegex = regexp.MustCompile(`\{([a-zA-Z_]+)(?::([^}]+))?\}`)
+
+// cronFields describes the five fields of a unix-cron schedule, with the range
+// of values each accepts and any names that can be used instead of numbers.
+var cronFields = []struct {
+	Name     string
+	Min, Max int
+	Names    []string
+}{
+	{Name: "minute", Min: 0, Max: 59},
+	{Name: "hour", Min: 0, Max: 23},
+	{Name: "day of month", Min: 1, Max: 31},
+	{Name: "month", Min: 1, Max: 12, Names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{Name: "day of week", Min: 0, Max: 7, Names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+var cronItemRegex = regexp.MustCompile(`^(?:\*|([0-9A-Za-z]+)(?:-([0-9A-Za-z]+))?)(?:/([0-9]+))?$`)
+
 var rfc1918Networks = []string{
 	"10.0.0.0/8",
 	"172.16.0.0/12",
@@ -65,10 +188,8 @@ func validateRFC1918Network(min, max int) schema.SchemaValidateFunc {
 
 		v, _ := i.(string)
 		ip, _, _ := net.ParseCIDR(v)
-		for _, c := range rfc1918Networks {
-			if _, ipnet, _ := net.ParseCIDR(c); ipnet.Contains(ip) {
-				return
-			}
+		if isRFC1918(ip) {
+			return
 		}
 
 		es = append(es, fmt.Errorf("expected %q to be an RFC1918-compliant CIDR, got: %s", k, v))
@@ -77,6 +198,16 @@ func validateRFC1918Network(min, max int) schema.SchemaValidateFunc {
 	}
 }
 
+// isRFC1918 reports whether ip is within one of the RFC1918 private ranges.
+func isRFC1918(ip net.IP) bool {
+	for _, c := range rfc1918Networks {
+		if _, ipnet, _ := net.ParseCIDR(c); ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateRFC3339Time(v interface{}, k string) (warnings []string, errors []error) {
 	time := v.(string)
 	if len(time) != 5 || time[2] != ':' {
@@ -130,3 +261,1713 @@ func validateCloudIoTID(v interface{}, k string) (warnings []string, errors []er
 	}
 	return
 }
+
+// validateGCSBucketName checks a bucket name against the Cloud Storage naming
+// requirements. Names containing dots may be up to 222 characters long, as
+// long as each dot-separated component is at most 63 characters.
+func validateGCSBucketName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 3 || len(value) > 222 {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be between 3 and 222 characters long", k, value))
+		return
+	}
+	if !regexp.MustCompile(GCSBucketNameRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must contain only lowercase letters, numbers, dashes, underscores and dots, and start and end with a letter or number", k, value))
+		return
+	}
+	for _, component := range strings.Split(value, ".") {
+		if len(component) > 63 {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) has a dot-separated component longer than 63 characters", k, value))
+		}
+	}
+	if !strings.Contains(value, ".") && len(value) > 63 {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be at most 63 characters long unless it contains dots", k, value))
+	}
+	if net.ParseIP(value) != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) can not be an IP address", k, value))
+	}
+	if strings.HasPrefix(value, "goog") {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) can not start with \"goog\"", k, value))
+	}
+	return
+}
+
+// validateGCSURI checks that a value is a gs://bucket/object URI with a valid
+// bucket name. If requireObject is set, the object path must be non-empty.
+func validateGCSURI(requireObject bool) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if !strings.HasPrefix(value, "gs://") {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) has an invalid scheme, expected a URI of the form gs://bucket/object", k, value))
+			return
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(value, "gs://"), "/", 2)
+		if _, es := validateGCSBucketName(parts[0], k); len(es) > 0 {
+			for _, err := range es {
+				errors = append(errors, fmt.Errorf("%q (%q) has an invalid bucket: %s", k, value, err))
+			}
+		}
+
+		if requireObject && (len(parts) < 2 || parts[1] == "") {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) is missing an object path, expected a URI of the form gs://bucket/object", k, value))
+		}
+		return
+	}
+}
+
+// canonicalEnumValue looks up value in valid ignoring case, returning the
+// canonical spelling of the matching entry.
+func canonicalEnumValue(value string, valid []string) (string, bool) {
+	for _, v := range valid {
+		if strings.EqualFold(value, v) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// validateIPProtocol accepts either a named IP protocol or a protocol number
+// between 0 and 255. Named protocols are matched case-insensitively, with a
+// warning if the value isn't in the canonical (upper) case.
+func validateIPProtocol(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 0 || n > 255 {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) must be a protocol number between 0 and 255", k, value))
+		}
+		return
+	}
+
+	canonical, ok := canonicalEnumValue(value, ipProtocols)
+	if !ok {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be one of %s, or a protocol number between 0 and 255", k, value, strings.Join(ipProtocols, ", ")))
+		return
+	}
+	if canonical != value {
+		ws = append(ws, fmt.Sprintf("%q (%q) should be written as %q", k, value, canonical))
+	}
+	return
+}
+
+// validateMasterCIDR checks a GKE private cluster master CIDR block, which must
+// be an RFC1918 /28 network address.
+func validateMasterCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	_, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid CIDR: %s", k, value, err))
+		return
+	}
+	if size, _ := ipnet.Mask.Size(); size != 28 {
+		errors = append(errors, fmt.Errorf("%q (%q) must have a prefix length of exactly 28, got /%d", k, value, size))
+		return
+	}
+	if ipnet.String() != value {
+		errors = append(errors, fmt.Errorf("%q (%q) must be a network address with all host bits set to zero, expected %q", k, value, ipnet.String()))
+		return
+	}
+	return validateRFC1918Network(28, 28)(v, k)
+}
+
+// validateWithSentinels wraps a validator so that special sentinel values (such
+// as "-" meaning "use the default") are accepted without being passed on.
+func validateWithSentinels(sentinels []string, inner schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if value, ok := v.(string); ok {
+			for _, sentinel := range sentinels {
+				if value == sentinel {
+					return
+				}
+			}
+		}
+		return inner(v, k)
+	}
+}
+
+// validateSQLTier checks a Cloud SQL tier against the custom, predefined and
+// legacy first generation tier shapes. Custom tiers must request memory in
+// multiples of 256MB.
+func validateSQLTier(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if m := regexp.MustCompile(SQLCustomTierRegex).FindStringSubmatch(value); m != nil {
+		if memory, _ := strconv.Atoi(m[2]); memory%256 != 0 {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) must request memory in a multiple of 256MB, got %dMB", k, value, memory))
+		}
+		return
+	}
+	if regexp.MustCompile(SQLStandardTierRegex).MatchString(value) || regexp.MustCompile(SQLLegacyTierRegex).MatchString(value) {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q (%q) must be a custom tier (db-custom-<cpus>-<memoryMB>), a predefined tier (such as db-n1-standard-1 or db-f1-micro) or a legacy tier (such as D0 or D1)", k, value))
+	return
+}
+
+// validateDuration checks that a value parses as a Go duration string, such as
+// "60s" or "1h30m".
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, err := time.ParseDuration(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid duration: %s", k, value, err))
+	}
+	return
+}
+
+// validateAlignmentPeriod checks a Stackdriver Monitoring alignment period,
+// which is a duration of whole seconds no shorter than 60s.
+func validateAlignmentPeriod(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validateDuration(v, k)
+	if len(errors) > 0 {
+		return
+	}
+
+	value := v.(string)
+	d, _ := time.ParseDuration(value)
+	if d%time.Second != 0 || d < time.Minute {
+		errors = append(errors, fmt.Errorf("%q (%q) must be a whole number of seconds and at least 60s", k, value))
+	}
+	return
+}
+
+func validateComparisonOperator(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(monitoringComparisons, false)(v, k)
+}
+
+// intValue reads an integer from a field value, which may be an int or, for
+// fields that are stored as strings, a base 10 integer string.
+func intValue(v interface{}) (int, error) {
+	switch value := v.(type) {
+	case int:
+		return value, nil
+	case string:
+		return strconv.Atoi(value)
+	}
+	return 0, fmt.Errorf("expected an int or a string, got %T", v)
+}
+
+// validateAckDeadline checks a Pub/Sub subscription ack deadline, which must be
+// between 10 and 600 seconds.
+func validateAckDeadline(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 10 || n > 600 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 10 and 600 seconds", k, n))
+	}
+	return
+}
+
+// validateRetentionDays checks a logging bucket retention period, which must be
+// between 1 and 3650 days.
+func validateRetentionDays(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 1 || n > 3650 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 1 and 3650 days", k, n))
+	}
+	return
+}
+
+// Workload Identity pool and provider ids share the same rules: 4 to 32
+// lowercase letters, digits or dashes, not starting with the reserved "gcp-"
+// prefix.
+func validateWorkloadIdentityPoolID(v interface{}, k string) (ws []string, errors []error) {
+	return validateWorkloadIdentityID(v, k)
+}
+
+func validateWorkloadIdentityProviderID(v interface{}, k string) (ws []string, errors []error) {
+	return validateWorkloadIdentityID(v, k)
+}
+
+func validateWorkloadIdentityID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.HasPrefix(value, "gcp-") {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) can not start with the reserved prefix \"gcp-\"", k, value))
+	}
+	if !regexp.MustCompile(WorkloadIdentityIdRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be 4 to 32 characters long and contain only lowercase letters, digits and dashes", k, value))
+	}
+	return
+}
+
+// validatePEMCertificate checks that a value contains at least one PEM encoded
+// CERTIFICATE block.
+func validatePEMCertificate(v interface{}, k string) (ws []string, errors []error) {
+	return validatePEMBlockType(v, k, []string{"CERTIFICATE"})
+}
+
+// validatePEMPrivateKey checks that a value contains a PEM encoded PKCS#8,
+// PKCS#1 (RSA) or EC private key block.
+func validatePEMPrivateKey(v interface{}, k string) (ws []string, errors []error) {
+	return validatePEMBlockType(v, k, []string{"PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY"})
+}
+
+func validatePEMBlockType(v interface{}, k string, types []string) (ws []string, errors []error) {
+	value := v.(string)
+	var found []string
+	for rest := []byte(value); ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		for _, t := range types {
+			if block.Type == t {
+				return
+			}
+		}
+		found = append(found, block.Type)
+	}
+
+	if len(found) == 0 {
+		errors = append(errors, fmt.Errorf("%q could not be decoded as PEM", k))
+		return
+	}
+	errors = append(errors, fmt.Errorf("%q contains PEM blocks of type %s, expected one of %s",
+		k, strings.Join(found, ", "), strings.Join(types, ", ")))
+	return
+}
+
+// validateDiskType checks a disk type name or self link against the types that
+// are available for zonal or regional disks. Self links must also be of the
+// matching scope.
+func validateDiskType(regional bool) schema.SchemaValidateFunc {
+	valid, scope, scopePath := zonalDiskTypes, "zonal", "/zones/"
+	if regional {
+		valid, scope, scopePath = regionalDiskTypes, "regional", "/regions/"
+	}
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		diskType := value
+		if strings.Contains(value, "/") {
+			if !strings.Contains("/"+value, scopePath) || !strings.Contains(value, "/diskTypes/") {
+				errors = append(errors, fmt.Errorf(
+					"%q (%q) must be a %s disk type link containing %q", k, value, scope, scopePath+"<location>/diskTypes/"))
+				return
+			}
+			diskType = GetResourceNameFromSelfLink(value)
+		}
+
+		for _, t := range valid {
+			if diskType == t {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) is not a valid %s disk type, expected one of %s", k, value, scope, strings.Join(valid, ", ")))
+		return
+	}
+}
+
+// validateSAKeyAlgorithm checks a service account key algorithm, warning when
+// the weaker 1024 bit RSA algorithm is chosen.
+func validateSAKeyAlgorithm(v interface{}, k string) (ws []string, errors []error) {
+	return validateEnumWithDeprecations(serviceAccountKeyAlgorithms, map[string]string{
+		"KEY_ALG_RSA_1024": "KEY_ALG_RSA_1024 is a weak key algorithm, consider using KEY_ALG_RSA_2048 instead",
+	})(v, k)
+}
+
+func validateSAKeyType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(serviceAccountPrivateKeyTypes, false)(v, k)
+}
+
+// validateVersionedName checks a "<name><sep><version>" reference, such as
+// "my-secret@latest". The name is checked with nameValidator, and the version
+// must be a positive integer or "latest". The version suffix is optional.
+func validateVersionedName(nameValidator schema.SchemaValidateFunc, sep string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		parts := strings.SplitN(value, sep, 2)
+
+		nws, nes := nameValidator(parts[0], k)
+		ws = append(ws, nws...)
+		for _, err := range nes {
+			errors = append(errors, fmt.Errorf("%q (%q) has an invalid name: %s", k, value, err))
+		}
+
+		if len(parts) == 2 && parts[1] != "latest" {
+			if n, err := strconv.Atoi(parts[1]); err != nil || n < 1 {
+				errors = append(errors, fmt.Errorf(
+					"%q (%q) has an invalid version %q, expected a positive integer or \"latest\"", k, value, parts[1]))
+			}
+		}
+		return
+	}
+}
+
+func validateBigtableInstanceType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"DEVELOPMENT", "PRODUCTION"}, false)(v, k)
+}
+
+func validateComposerEnvName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 64 {
+		errors = append(errors, fmt.Errorf("%q (%q) must be at most 64 characters long", k, value))
+		return
+	}
+	return validateRegexp(ComposerEnvironmentNameRegex)(v, k)
+}
+
+// validateComposerImageVersion checks a Composer image version, such as
+// "composer-1.4.0-airflow-1.10.0", "composer-1-airflow-1.10" or
+// "composer-latest-airflow-1.10".
+func validateComposerImageVersion(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(ComposerImageVersionRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be of the form %s, such as composer-1.4.0-airflow-1.10.0", k, value, ComposerImageVersionTemplate))
+	}
+	return
+}
+
+func validateCloudRunServiceName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 63 {
+		errors = append(errors, fmt.Errorf("%q (%q) must be at most 63 characters long", k, value))
+		return
+	}
+	return validateGCPName(v, k)
+}
+
+// validateContainerConcurrency checks a Cloud Run container concurrency, which
+// is between 0 and 1000 requests. 0 means concurrency is unlimited.
+func validateContainerConcurrency(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 0 || n > 1000 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 0 and 1000, where 0 means unlimited", k, n))
+	}
+	return
+}
+
+// validateVPCConnectorName checks a Serverless VPC Access connector name, which
+// is limited to 25 characters rather than the usual 63.
+func validateVPCConnectorName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 25 {
+		errors = append(errors, fmt.Errorf("%q (%q) must be at most 25 characters long for a VPC connector", k, value))
+		return
+	}
+	return validateGCPName(v, k)
+}
+
+func validateVPCConnectorMachineType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"f1-micro", "e2-micro", "e2-standard-4"}, false)(v, k)
+}
+
+func validateNonNegativeInt(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 0 {
+		errors = append(errors, fmt.Errorf("%q (%d) must not be negative", k, n))
+	}
+	return
+}
+
+// validateManagedZoneName checks a Cloud DNS managed zone name, which follows
+// the usual RFC1035 rules and is at most 63 characters long.
+func validateManagedZoneName(v interface{}, k string) (ws []string, errors []error) {
+	return validateGCPName(v, k)
+}
+
+func validateNotificationChannelType(v interface{}, k string) (ws []string, errors []error) {
+	types := make([]string, 0, len(channelRequiredLabels))
+	for t := range channelRequiredLabels {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return validation.StringInSlice(types, false)(v, k)
+}
+
+// validateRelativeResourceName checks a value against a relative resource name
+// template such as "projects/{project}/locations/{location}/queues/{queue}".
+// A {placeholder} matches a single non-empty path segment, unless it carries
+// its own pattern, as in "brands/{brand:[0-9]+}".
+func validateRelativeResourceName(template string) schema.SchemaValidateFunc {
+	re, shape := parseRelativeResourceNameTemplate(template)
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if !re.MatchString(value) {
+			errors = append(errors, fmt.Errorf("%q (%q) must be of the form %q", k, value, shape))
+		}
+		return
+	}
+}
+
+// parseRelativeResourceNameTemplate returns a regexp matching the names
+// described by a relative resource name template, and the template with any
+// placeholder patterns removed, for use in error messages.
+func parseRelativeResourceNameTemplate(template string) (*regexp.Regexp, string) {
+	var re, shape string
+	last := 0
+	for _, m := range relativeNamePlaceholderRegex.FindAllStringSubmatchIndex(template, -1) {
+		literal := template[last:m[0]]
+		name := template[m[2]:m[3]]
+		pattern := "[^/]+"
+		if m[4] >= 0 {
+			pattern = template[m[4]:m[5]]
+		}
+		re += regexp.QuoteMeta(literal) + "(?:" + pattern + ")"
+		shape += literal + "{" + name + "}"
+		last = m[1]
+	}
+	re = "^" + re + regexp.QuoteMeta(template[last:]) + "$"
+	shape += template[last:]
+
+	return regexp.MustCompile(re), shape
+}
+
+func validateIAPBrandName(v interface{}, k string) (ws []string, errors []error) {
+	return validateRelativeResourceName("brands/{brand:[0-9]+}")(v, k)
+}
+
+func validateIAPClientName(v interface{}, k string) (ws []string, errors []error) {
+	return validateRelativeResourceName("brands/{brand:[0-9]+}/identityAwareProxyClients/{client}")(v, k)
+}
+
+func validateTasksQueueName(v interface{}, k string) (ws []string, errors []error) {
+	return validateRelativeResourceName("projects/{project}/locations/{location}/queues/{queue}")(v, k)
+}
+
+// validateMaxConcurrentDispatches checks a Cloud Tasks queue's concurrent
+// dispatch limit, which must be between 1 and 5000.
+func validateMaxConcurrentDispatches(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 1 || n > 5000 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 1 and 5000", k, n))
+	}
+	return
+}
+
+func validateFilestoreTier(v interface{}, k string) (ws []string, errors []error) {
+	tiers := make([]string, 0, len(filestoreTierMinCapacityGb))
+	for t := range filestoreTierMinCapacityGb {
+		tiers = append(tiers, t)
+	}
+	sort.Strings(tiers)
+	return validation.StringInSlice(tiers, false)(v, k)
+}
+
+// validateRegexpSyntax checks that a value is itself a valid regular
+// expression, for fields such as trigger branch and tag filters.
+func validateRegexpSyntax(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, err := regexp.Compile(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid regular expression: %s", k, value, err))
+	}
+	return
+}
+
+// validateAll runs each of the validators in turn, collecting all of their
+// warnings and errors.
+func validateAll(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		for _, validator := range validators {
+			vws, ves := validator(v, k)
+			ws = append(ws, vws...)
+			errors = append(errors, ves...)
+		}
+		return
+	}
+}
+
+// validateIntMultipleOf checks that an int, or an int stored as a string, is a
+// multiple of step.
+func validateIntMultipleOf(step int) schema.SchemaValidateFunc {
+	if step < 1 {
+		return func(i interface{}, k string) (s []string, errors []error) {
+			errors = append(errors, fmt.Errorf("step must be at least 1. Got: %d", step))
+			return
+		}
+	}
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		n, err := intValue(v)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+			return
+		}
+		if n%step != 0 {
+			errors = append(errors, fmt.Errorf("%q (%d) must be a multiple of %d", k, n, step))
+		}
+		return
+	}
+}
+
+// validateRegion checks that a value has the shape of a region name, such as
+// "us-central1" or "northamerica-northeast1".
+func validateRegion(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(RegionNameRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid region name, such as \"us-central1\"", k, value))
+	}
+	return
+}
+
+// validateGCRLocation checks a Container Registry location, which is one of
+// the us, eu or asia multi-regions, or empty for the global gcr.io host.
+func validateGCRLocation(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"", "us", "eu", "asia"}, false)(v, k)
+}
+
+// validateArtifactRegistryLocation checks an Artifact Registry location, which
+// is either a region or one of the us, europe or asia multi-regions.
+func validateArtifactRegistryLocation(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "us", "europe", "asia":
+		return
+	}
+	return validateRegion(v, k)
+}
+
+func validateSQLFlagName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(SQLFlagNameRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must contain only lowercase letters, numbers, underscores and dots, such as \"slow_query_log\"", k, value))
+	}
+	return
+}
+
+// validateRegionInList checks that a value is one of the known regions, and
+// suggests the closest known region when it isn't.
+func validateRegionInList(regions []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, region := range regions {
+			if value == region {
+				return
+			}
+		}
+
+		if suggestion, ok := closestMatch(value, regions); ok {
+			errors = append(errors, fmt.Errorf("%q (%q) is not a known region, did you mean %s?", k, value, suggestion))
+			return
+		}
+		errors = append(errors, fmt.Errorf("%q (%q) is not a known region", k, value))
+		return
+	}
+}
+
+// closestMatch returns the candidate with the smallest edit distance to value,
+// as long as it is close enough to plausibly be a typo.
+func closestMatch(value string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		if d := levenshteinDistance(value, c); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	maxDistance := len(value) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	return best, bestDistance >= 0 && bestDistance <= maxDistance
+}
+
+// levenshteinDistance returns the number of single character insertions,
+// deletions and substitutions needed to turn a into b.
+func levenshteinDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+// validateNotAlreadyBase64 warns when a value that Terraform will base64 encode
+// already looks like base64 encoded binary data, which usually means it is
+// about to be encoded twice. It only warns, since a plaintext value can
+// happen to look like base64. Most alphanumeric strings decode to binary, so
+// the value must also be padded or use the "+" and "/" characters, which
+// plaintext secrets rarely combine with a length that decodes cleanly.
+func validateNotAlreadyBase64(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 16 || len(value)%4 != 0 {
+		return
+	}
+	padded := strings.HasSuffix(value, "=")
+	if !padded && (len(value) < 24 || !strings.ContainsAny(value, "+/")) {
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return
+	}
+	for _, b := range decoded {
+		if (b < 0x20 || b > 0x7e) && b != '\n' && b != '\r' && b != '\t' {
+			ws = append(ws, fmt.Sprintf("%q looks like it is already base64 encoded, and will be encoded again. Pass the raw value instead, for example with base64decode()", k))
+			return
+		}
+	}
+	return
+}
+
+// validateSelfLink checks that a value is a Compute API self link, such as
+// "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance".
+func validateSelfLink(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(ComputeSelfLinkRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be a self link of the form \"https://www.googleapis.com/compute/v1/projects/{project}/...\"", k, value))
+	}
+	return
+}
+
+// validateSelfLinkOrRelativeName checks that a value is either a relative
+// resource name matching template, or a self link to such a resource.
+func validateSelfLinkOrRelativeName(template string) schema.SchemaValidateFunc {
+	re, shape := parseRelativeResourceNameTemplate(template)
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		path := value
+		if strings.HasPrefix(value, "https://") {
+			if ws, errors = validateSelfLink(v, k); len(errors) > 0 {
+				return
+			}
+			path, _ = getRelativePath(value)
+		}
+
+		if !re.MatchString(path) {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) must be a self link or a relative name of the form %q", k, value, shape))
+		}
+		return
+	}
+}
+
+func validateMachineImageName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 63 {
+		errors = append(errors, fmt.Errorf("%q (%q) must be at most 63 characters long", k, value))
+		return
+	}
+	return validateGCPName(v, k)
+}
+
+func validateMachineImageSourceInstance(v interface{}, k string) (ws []string, errors []error) {
+	return validateSelfLinkOrRelativeName("projects/{project}/zones/{zone}/instances/{instance}")(v, k)
+}
+
+// validateNetworkName checks a VPC network name: 1 to 63 characters following
+// the RFC1035 rules.
+func validateNetworkName(v interface{}, k string) (ws []string, errors []error) {
+	return validateRegexp(fmt.Sprintf("^(?:[a-z]|"+RFC1035NameTemplate+")$", 0, 61))(v, k)
+}
+
+// validateZone checks that a value has the shape of a zone name, such as
+// "us-central1-a".
+func validateZone(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(ZoneNameRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid zone name, such as \"us-central1-a\"", k, value))
+	}
+	return
+}
+
+// validateBackendBucketName checks the Cloud Storage bucket that a backend
+// bucket serves from.
+func validateBackendBucketName(v interface{}, k string) (ws []string, errors []error) {
+	return validateGCSBucketName(v, k)
+}
+
+func validateCDNCacheMode(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"USE_ORIGIN_HEADERS", "FORCE_CACHE_ALL", "CACHE_ALL_STATIC"}, false)(v, k)
+}
+
+func validateSSLPolicyProfile(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"COMPATIBLE", "MODERN", "RESTRICTED", "CUSTOM"}, false)(v, k)
+}
+
+// validateCronSchedule checks a unix-cron schedule of five space separated
+// fields, such as "0 9 * * MON-FRI".
+func validateCronSchedule(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	fields := strings.Fields(value)
+	if len(fields) != len(cronFields) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be a unix-cron schedule with 5 fields (minute hour day-of-month month day-of-week), got %d fields", k, value, len(fields)))
+		return
+	}
+
+	for i, field := range fields {
+		spec := cronFields[i]
+		for _, item := range strings.Split(field, ",") {
+			m := cronItemRegex.FindStringSubmatch(item)
+			if m == nil {
+				errors = append(errors, fmt.Errorf("%q (%q) has an invalid %s field %q", k, value, spec.Name, field))
+				break
+			}
+			for _, bound := range m[1:3] {
+				if bound == "" {
+					continue
+				}
+				if _, ok := canonicalEnumValue(bound, spec.Names); ok {
+					continue
+				}
+				if n, err := strconv.Atoi(bound); err != nil || n < spec.Min || n > spec.Max {
+					errors = append(errors, fmt.Errorf(
+						"%q (%q) has an invalid %s %q, expected a value between %d and %d", k, value, spec.Name, bound, spec.Min, spec.Max))
+				}
+			}
+		}
+	}
+	return
+}
+
+var timeZoneRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+-]*(?:/[A-Za-z0-9_+-]+)*$`)
+
+// validateTimeZone checks that a value has the shape of an IANA time zone
+// name, such as "America/New_York" or "UTC". Names aren't checked against the
+// time zone database, as it may not be installed where Terraform runs.
+func validateTimeZone(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "Local" || !timeZoneRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q (%q) must be an IANA time zone name, such as \"America/New_York\"", k, value))
+	}
+	return
+}
+
+// validateEnumWithDeprecations checks that a value is one of valid, or one of
+// the keys of deprecated. Deprecated values are still accepted, but emit a
+// warning with the message they map to, which should suggest a replacement.
+func validateEnumWithDeprecations(valid []string, deprecated map[string]string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if msg, ok := deprecated[value]; ok {
+			ws = append(ws, fmt.Sprintf("%q (%q) is deprecated: %s", k, value, msg))
+			return
+		}
+		return validation.StringInSlice(valid, false)(v, k)
+	}
+}
+
+// validateCIDRNotDefaultRoute checks that a value is a CIDR range other than
+// the IPv4 or IPv6 default route.
+func validateCIDRNotDefaultRoute(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	_, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid CIDR: %s", k, value, err))
+		return
+	}
+	if ones, _ := ipnet.Mask.Size(); ones == 0 {
+		errors = append(errors, fmt.Errorf("%q (%q) is a default route, which isn't permitted here", k, value))
+	}
+	return
+}
+
+// selfLinkScopeRegexes matches the path segment identifying the scope of a
+// compute resource self link.
+var selfLinkScopeRegexes = map[string]*regexp.Regexp{
+	"global":   regexp.MustCompile("/projects/[^/]+/global/"),
+	"regional": regexp.MustCompile("/projects/[^/]+/regions/[^/]+/"),
+	"zonal":    regexp.MustCompile("/projects/[^/]+/zones/[^/]+/"),
+}
+
+// validateSelfLinkScope checks that a self link refers to a resource of the
+// given scope, one of "global", "regional" or "zonal".
+func validateSelfLinkScope(scope string) schema.SchemaValidateFunc {
+	re, ok := selfLinkScopeRegexes[scope]
+	if !ok {
+		return func(i interface{}, k string) (s []string, errors []error) {
+			errors = append(errors, fmt.Errorf("scope must be one of \"global\", \"regional\" or \"zonal\". Got: %q", scope))
+			return
+		}
+	}
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if !re.MatchString(value) {
+			errors = append(errors, fmt.Errorf("%q (%q) must be a self link to a %s resource", k, value, scope))
+		}
+		return
+	}
+}
+
+// renamedServices maps legacy service names, which the Service Usage API may
+// still accept, to the names they have been replaced by.
+var renamedServices = map[string]string{
+	"bigquery-json.googleapis.com": "bigquery.googleapis.com",
+}
+
+var enableServiceNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*(?:\.[a-z][a-z0-9-]*)*\.googleapis\.com$`)
+
+// validateEnableServiceName checks the name of a service to enable, such as
+// "compute.googleapis.com", warning when a legacy name has been renamed.
+func validateEnableServiceName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !enableServiceNameRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q (%q) must be a service name of the form \"<service>.googleapis.com\"", k, value))
+		return
+	}
+	if renamed, ok := renamedServices[value]; ok {
+		ws = append(ws, fmt.Sprintf("%q (%q) has been renamed, use %q instead", k, value, renamed))
+	}
+	return
+}
+
+// validateClusterName checks a GKE cluster name. Cluster names are RFC1035
+// labels, limited to 40 characters so that the names GKE derives from them,
+// such as node pool instance group names, stay within their own limits.
+func validateClusterName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) > 40 {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) cannot be longer than 40 characters", k, value))
+	}
+	if !regexp.MustCompile("^[a-z0-9-]+$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) can only contain lowercase letters, numbers and hyphens", k, value))
+	}
+	if !regexp.MustCompile("^[a-z]").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must start with a letter", k, value))
+	}
+	if !regexp.MustCompile("[a-z0-9]$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must end with a number or a letter", k, value))
+	}
+	return
+}
+
+// validatePublicCIDR checks that a CIDR range, or a single IP address, is
+// public rather than within an RFC1918 private range, as is expected of
+// fields such as Cloud SQL authorized networks.
+func validatePublicCIDR(v interface{}, k string) (ws []string, errors []error) {
+	return publicCIDRValidator(false)(v, k)
+}
+
+// publicCIDRValidator returns a validator like validatePublicCIDR, which only
+// warns about private ranges when warnOnly is set.
+func publicCIDRValidator(warnOnly bool) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		ip := net.ParseIP(value)
+		if ip == nil {
+			var err error
+			if ip, _, err = net.ParseCIDR(value); err != nil {
+				errors = append(errors, fmt.Errorf("%q (%q) is not a valid IP address or CIDR: %s", k, value, err))
+				return
+			}
+		}
+
+		if !isRFC1918(ip) {
+			return
+		}
+		msg := fmt.Sprintf("%q (%q) is a private RFC1918 range, but authorized networks should be public IP ranges", k, value)
+		if warnOnly {
+			ws = append(ws, msg)
+		} else {
+			errors = append(errors, fmt.Errorf("%s", msg))
+		}
+		return
+	}
+}
+
+var negTypes = []string{
+	"GCE_VM_IP",
+	"GCE_VM_IP_PORT",
+	"NON_GCP_PRIVATE_IP_PORT",
+	"INTERNET_IP_PORT",
+	"INTERNET_FQDN_PORT",
+	"SERVERLESS",
+	"PRIVATE_SERVICE_CONNECT",
+}
+
+// negTypesWithPort lists the network endpoint types whose endpoints have a
+// port, and so accept a default_port.
+var negTypesWithPort = []string{
+	"GCE_VM_IP_PORT",
+	"NON_GCP_PRIVATE_IP_PORT",
+	"INTERNET_IP_PORT",
+	"INTERNET_FQDN_PORT",
+}
+
+func validateNEGType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(negTypes, false)(v, k)
+}
+
+// validateEnumCanonicalCase checks that a value is one of valid, matched
+// case-insensitively, with a warning if it isn't in the canonical case.
+func validateEnumCanonicalCase(valid []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		canonical, ok := canonicalEnumValue(value, valid)
+		if !ok {
+			errors = append(errors, fmt.Errorf("%q (%q) must be one of %s", k, value, strings.Join(valid, ", ")))
+			return
+		}
+		if canonical != value {
+			ws = append(ws, fmt.Sprintf("%q (%q) should be written as %q", k, value, canonical))
+		}
+		return
+	}
+}
+
+// validateIndexDirection checks the direction of a Datastore or Firestore
+// index field.
+func validateIndexDirection(v interface{}, k string) (ws []string, errors []error) {
+	return validateEnumCanonicalCase([]string{"ASCENDING", "DESCENDING"})(v, k)
+}
+
+// validateArrayConfig checks the array config of a Firestore index field.
+func validateArrayConfig(v interface{}, k string) (ws []string, errors []error) {
+	return validateEnumCanonicalCase([]string{"CONTAINS"})(v, k)
+}
+
+// validateURL checks that a value is an absolute URL with a host, using one of
+// the given schemes.
+func validateURL(schemes []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		u, err := url.Parse(value)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%q (%q) is not a valid URL: %s", k, value, err))
+			return
+		}
+		if u.Host == "" {
+			errors = append(errors, fmt.Errorf("%q (%q) must be an absolute URL with a host", k, value))
+			return
+		}
+		for _, scheme := range schemes {
+			if u.Scheme == scheme {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("%q (%q) must use one of the schemes %s", k, value, strings.Join(schemes, ", ")))
+		return
+	}
+}
+
+// validatePushEndpoint checks a Pub/Sub push endpoint, which must be an https
+// URL.
+func validatePushEndpoint(v interface{}, k string) (ws []string, errors []error) {
+	if _, es := validateURL([]string{"https"})(v, k); len(es) > 0 {
+		errors = append(errors, fmt.Errorf("%q (%q) must be an https URL, as Pub/Sub requires push endpoints to use https", k, v.(string)))
+	}
+	return
+}
+
+func validateShareType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"LOCAL", "SPECIFIC_PROJECTS"}, false)(v, k)
+}
+
+// validateNamePrefix checks a name_prefix, to which Terraform appends a 26
+// character unique suffix to generate the resource name. The prefix is
+// limited to maxPrefix characters to leave room for that suffix.
+func validateNamePrefix(maxPrefix int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if len(value) > maxPrefix {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) cannot be longer than %d characters, as a 26 character unique suffix is appended to it", k, value, maxPrefix))
+		}
+		if !regexp.MustCompile("^[a-z][-a-z0-9]*$").MatchString(value) {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) must start with a lowercase letter, followed by lowercase letters, numbers or hyphens", k, value))
+		}
+		return
+	}
+}
+
+// reservedMetadataKeys maps instance metadata keys that are managed through a
+// dedicated field, or by GCP itself, to a suggestion of what to use instead.
+var reservedMetadataKeys = map[string]string{
+	"startup-script":            "use metadata_startup_script instead",
+	"gce-container-declaration": "it is managed by the container-optimized OS tooling, such as gcloud's create-with-container",
+	"google-logging-enabled":    "it is managed by the container-optimized OS tooling, such as gcloud's create-with-container",
+}
+
+var metadataKeyRegex = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+
+// validateMetadataKey checks an instance metadata key, warning when the key is
+// reserved.
+func validateMetadataKey(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !metadataKeyRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be between 1 and 128 characters long and contain only letters, numbers, hyphens and underscores", k, value))
+		return
+	}
+	if suggestion, ok := reservedMetadataKeys[value]; ok {
+		ws = append(ws, fmt.Sprintf("%q (%q) is a reserved metadata key, %s", k, value, suggestion))
+	}
+	return
+}
+
+// validateBackupRetentionCount checks the number of Cloud SQL backups to
+// retain.
+func validateBackupRetentionCount(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 1 || n > 365 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 1 and 365 backups", k, n))
+	}
+	return
+}
+
+func validateCAPoolTier(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"ENTERPRISE", "DEVOPS"}, false)(v, k)
+}
+
+// caCertificateMaxLifetime is the longest lifetime allowed for a certificate
+// issued by Certificate Authority Service, 10 years.
+const caCertificateMaxLifetime = 10 * 365 * 24 * time.Hour
+
+// validateCertificateLifetime checks a certificate lifetime, such as
+// "2592000s", which must be positive and no longer than 10 years.
+func validateCertificateLifetime(v interface{}, k string) (ws []string, errors []error) {
+	return validateDurationBetween(time.Second, caCertificateMaxLifetime)(v, k)
+}
+
+// validateDurationBetween checks that a value is a duration between min and
+// max, inclusive.
+func validateDurationBetween(min, max time.Duration) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		ws, errors = validateDuration(v, k)
+		if len(errors) > 0 {
+			return
+		}
+
+		value := v.(string)
+		d, _ := time.ParseDuration(value)
+		if d < min || d > max {
+			errors = append(errors, fmt.Errorf("%q (%q) must be between %s and %s", k, value, min, max))
+		}
+		return
+	}
+}
+
+// validateDataprocClusterName checks a Dataproc cluster name, a lowercase
+// RFC1035 label of at most 52 characters, which leaves room for the suffixes
+// Dataproc appends when naming the cluster's instances.
+func validateDataprocClusterName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 52 {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) cannot be longer than 52 characters, as Dataproc derives instance names from it", k, value))
+	}
+	if !regexp.MustCompile("^[a-z](?:[-a-z0-9]*[a-z0-9])?$").MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must start with a letter, end with a letter or number, and contain only lowercase letters, numbers and hyphens", k, value))
+	}
+	return
+}
+
+// vertexAIRegions lists the regions in which Vertex AI is available.
+var vertexAIRegions = []string{
+	"asia-east1",
+	"asia-east2",
+	"asia-northeast1",
+	"asia-northeast3",
+	"asia-south1",
+	"asia-southeast1",
+	"australia-southeast1",
+	"europe-west1",
+	"europe-west2",
+	"europe-west3",
+	"europe-west4",
+	"europe-west6",
+	"northamerica-northeast1",
+	"us-central1",
+	"us-east1",
+	"us-east4",
+	"us-west1",
+	"us-west2",
+}
+
+// vertexAIRegionExamples are the supported regions listed in
+// validateVertexRegion's error, one from each continent.
+const vertexAIRegionExamples = "us-central1, europe-west4 and asia-east1"
+
+// validateVertexRegion checks that a region is one Vertex AI is available in,
+// suggesting the closest supported region when it isn't.
+func validateVertexRegion(v interface{}, k string) (ws []string, errors []error) {
+	if _, es := validation.StringInSlice(vertexAIRegions, false)(v, k); len(es) == 0 {
+		return
+	}
+
+	value := v.(string)
+	suggestion := "."
+	if closest, ok := closestMatch(value, vertexAIRegions); ok {
+		suggestion = fmt.Sprintf(", did you mean %s?", closest)
+	}
+	errors = append(errors, fmt.Errorf("%q (%q) is not a region Vertex AI is available in%s Supported regions include %s",
+		k, value, suggestion, vertexAIRegionExamples))
+	return
+}
+
+func validateVertexResourceName(v interface{}, k string) (ws []string, errors []error) {
+	return validateRelativeResourceName("projects/{project}/locations/{location}/{collection:[a-zA-Z]+}/{id}")(v, k)
+}
+
+var datedImageNameRegex = regexp.MustCompile("-v?[0-9]{8}$")
+
+// validateComputeImage checks a value in any of the forms resolveImage
+// accepts, warning when it names a dated image rather than an image family,
+// which would pin instances to that image as newer ones are released.
+func validateComputeImage(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.HasPrefix(value, "https://") {
+		if ws, errors = validateSelfLink(v, k); len(errors) > 0 {
+			return
+		}
+	}
+
+	for _, re := range []*regexp.Regexp{resolveImageFamilyFamily, resolveImageGlobalFamily, resolveImageProjectFamily} {
+		if re.MatchString(value) {
+			return
+		}
+	}
+
+	images := []*regexp.Regexp{resolveImageLink, resolveImageProjectImage, resolveImageGlobalImage, resolveImageProjectImageShorthand, resolveImageImage}
+	for _, re := range images {
+		if value != "" && re.MatchString(value) {
+			name := GetResourceNameFromSelfLink(value)
+			if datedImageNameRegex.MatchString(name) {
+				ws = append(ws, fmt.Sprintf(
+					"%q (%q) refers to a dated image, consider using its image family, such as \"family/<family>\", instead", k, value))
+			}
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q (%q) must be an image or family in one of the forms \"family/{family}\", \"projects/{project}/global/images/{image}\", "+
+			"\"projects/{project}/global/images/family/{family}\", \"{project}/{image}\", \"{image}\" or a self link", k, value))
+	return
+}
+
+// checkFilterBalance checks that the quotes and parentheses of a Monitoring or
+// Logging filter are balanced. Parentheses within quoted strings, and quotes
+// escaped with a backslash, are ignored.
+func checkFilterBalance(filter string) error {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(filter); i++ {
+		switch c := filter[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return fmt.Errorf("unbalanced closing parenthesis at position %d", i)
+			}
+			depth--
+		}
+	}
+
+	if quoted {
+		return fmt.Errorf("unterminated quoted string")
+	}
+	if depth > 0 {
+		return fmt.Errorf("%d parentheses left unclosed", depth)
+	}
+	return nil
+}
+
+// validateLogFilter checks the syntax of a Logging filter, warning when it is
+// empty, as an empty filter matches every log entry.
+func validateLogFilter(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.TrimSpace(value) == "" {
+		ws = append(ws, fmt.Sprintf("%q is empty, and so matches every log entry", k))
+		return
+	}
+	if err := checkFilterBalance(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid filter: %s", k, value, err))
+	}
+	return
+}
+
+var (
+	orgPolicyConstraintRegex       = regexp.MustCompile(`^constraints/[a-z][a-zA-Z0-9]*(?:\.[a-zA-Z][a-zA-Z0-9]*)+$`)
+	orgPolicyCustomConstraintRegex = regexp.MustCompile(`^customConstraints/custom\.[a-zA-Z0-9]{1,70}$`)
+)
+
+// validateOrgPolicyConstraint checks the name of an Organization Policy
+// constraint, either a managed constraint or a custom constraint.
+func validateOrgPolicyConstraint(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !orgPolicyConstraintRegex.MatchString(value) && !orgPolicyCustomConstraintRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be of the form \"constraints/{service}.{constraint}\" or \"customConstraints/custom.{name}\"", k, value))
+	}
+	return
+}
+
+// validateTargetSize checks the target size of a managed instance group.
+func validateTargetSize(v interface{}, k string) (ws []string, errors []error) {
+	return validateNonNegativeInt(v, k)
+}
+
+var serviceAccountAccountIDRegex = regexp.MustCompile("^" + ServiceAccountNameRegex + "$")
+
+// validateServiceAccountAccountID checks the account id of a service account,
+// the local part of its email address.
+func validateServiceAccountAccountID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !serviceAccountAccountIDRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be between 6 and 30 characters long, start with a lowercase letter, end with a lowercase letter "+
+				"or number, and contain only lowercase letters, numbers and hyphens", k, value))
+	}
+	return
+}
+
+var (
+	sqlInstanceNameRegex     = regexp.MustCompile(`^(?:` + ProjectRegex + `:)?[a-z](?:[-a-z0-9]{0,96}[a-z0-9])?$`)
+	sqlInstanceSelfLinkRegex = regexp.MustCompile(`^https://www\.googleapis\.com/sql/v1beta4/projects/` + ProjectRegex + `/instances/[a-z](?:[-a-z0-9]{0,96}[a-z0-9])?$`)
+)
+
+// validateSQLMasterInstanceName checks a reference to the master of a Cloud
+// SQL replica: an instance name, optionally prefixed with "{project}:", or a
+// self link to the instance.
+func validateSQLMasterInstanceName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !sqlInstanceNameRegex.MatchString(value) && !sqlInstanceSelfLinkRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) must be a Cloud SQL instance name, of the form \"{instance}\" or \"{project}:{instance}\", or a self link to the instance", k, value))
+	}
+	return
+}
+
+func validateFirewallLogMetadata(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"EXCLUDE_ALL_METADATA", "INCLUDE_ALL_METADATA"}, false)(v, k)
+}
+
+var natSourceSubnetworkModes = []string{
+	"ALL_SUBNETWORKS_ALL_IP_RANGES",
+	"ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES",
+	"LIST_OF_SUBNETWORKS",
+}
+
+func validateNATSourceSubnetworkMode(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(natSourceSubnetworkModes, false)(v, k)
+}
+
+// validateNameServerIP checks the IP address of an alternative DNS name
+// server, which can't be a loopback or multicast address.
+func validateNameServerIP(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	ip := net.ParseIP(value)
+	if ip == nil {
+		errors = append(errors, fmt.Errorf("%q (%q) is not a valid IP address", k, value))
+		return
+	}
+	if ip.IsLoopback() {
+		errors = append(errors, fmt.Errorf("%q (%q) is a loopback address, which isn't allowed for a name server", k, value))
+	}
+	if ip.IsMulticast() {
+		errors = append(errors, fmt.Errorf("%q (%q) is a multicast address, which isn't allowed for a name server", k, value))
+	}
+	return
+}
+
+// validateSnapshotName checks a compute snapshot name, an RFC1035 label of at
+// most 63 characters.
+func validateSnapshotName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) > 63 {
+		errors = append(errors, fmt.Errorf("%q (%q) cannot be longer than 63 characters", k, value))
+		return
+	}
+	return validateGCPName(v, k)
+}
+
+// validateSnapshotSourceDisk checks the disk a snapshot is taken of, a self
+// link or relative name of a zonal or regional disk.
+func validateSnapshotSourceDisk(v interface{}, k string) (ws []string, errors []error) {
+	return validateSelfLinkOrRelativeName("projects/{project}/{scope:zones|regions}/{location}/disks/{disk}")(v, k)
+}
+
+var kmsImportMethods = []string{
+	"RSA_OAEP_3072_SHA1_AES_256",
+	"RSA_OAEP_4096_SHA1_AES_256",
+	"RSA_OAEP_3072_SHA256_AES_256",
+	"RSA_OAEP_4096_SHA256_AES_256",
+	"RSA_OAEP_3072_SHA256",
+	"RSA_OAEP_4096_SHA256",
+}
+
+var kmsProtectionLevels = []string{"SOFTWARE", "HSM", "EXTERNAL", "EXTERNAL_VPC"}
+
+func validateKMSImportMethod(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(kmsImportMethods, false)(v, k)
+}
+
+func validateKMSProtectionLevel(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(kmsProtectionLevels, false)(v, k)
+}
+
+// validateMaxInstanceCount checks the maximum instance count of a Cloud Run
+// service, which the default quota limits to 1000.
+func validateMaxInstanceCount(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 0 || n > 1000 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 0 and 1000", k, n))
+	}
+	return
+}
+
+var gkeReleaseChannels = []string{"UNSPECIFIED", "RAPID", "REGULAR", "STABLE"}
+
+func validateReleaseChannel(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice(gkeReleaseChannels, false)(v, k)
+}
+
+// validateTargetPoolSessionAffinity checks the session affinity of a target
+// pool, warning when it isn't written in the canonical (upper) case.
+func validateTargetPoolSessionAffinity(v interface{}, k string) (ws []string, errors []error) {
+	return validateEnumCanonicalCase([]string{"NONE", "CLIENT_IP", "CLIENT_IP_PROTO"})(v, k)
+}
+
+var bigQueryScheduleRegexes = func() []*regexp.Regexp {
+	clock := `(?:[01][0-9]|2[0-3]):[0-5][0-9]`
+	days := `(?:day|monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tue|wed|thu|fri|sat|sun)`
+	ordinals := `(?:[1-5](?:st|nd|rd|th)|first|second|third|fourth|fifth|last)`
+	months := `(?:month|january|february|march|april|may|june|july|august|september|october|november|december|` +
+		`jan|feb|mar|apr|jun|jul|aug|sep|oct|nov|dec)`
+	list := func(re string) string { return re + `(?:,` + re + `)*` }
+
+	return []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^every [1-9][0-9]* (?:minutes?|mins|hours?|days?)(?: from ` + clock + ` to ` + clock + `)?$`),
+		regexp.MustCompile(`(?i)^every ` + list(days) + ` ` + clock + `$`),
+		regexp.MustCompile(`(?i)^` + list(ordinals) + ` ` + list(days) + ` of ` + list(months) + ` ` + clock + `$`),
+	}
+}()
+
+// validateBigQuerySchedule checks the schedule of a BigQuery scheduled query,
+// which uses an English-like grammar rather than unix-cron.
+func validateBigQuerySchedule(v interface{}, k string) (ws []string, errors []error) {
+	value := strings.Join(strings.Fields(v.(string)), " ")
+	for _, re := range bigQueryScheduleRegexes {
+		if re.MatchString(value) {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf(
+		"%q (%q) is not a supported schedule, such as \"every 6 hours\", \"every monday 09:00\" or \"first sunday of sep 00:00\"", k, v))
+	return
+}
+
+// validateIPVersion checks an IP version. An empty value leaves the version to
+// the API, which defaults to IPV4.
+func validateIPVersion(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"IPV4", "IPV6", ""}, false)(v, k)
+}
+
+func validateRoutePriority(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 0 || n > 65535 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 0 and 65535", k, n))
+	}
+	return
+}
+
+// validateGlobalAddressName checks a reference to a global address, either by
+// name or by self link.
+func validateGlobalAddressName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.HasPrefix(value, "https://") {
+		if _, es := validateSelfLinkOrRelativeName("projects/{project}/global/addresses/{address}")(v, k); len(es) == 0 {
+			return
+		}
+	} else if _, es := validateGCPName(v, k); len(es) == 0 {
+		return
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q (%q) must be a global address name, or a self link of the form "+
+			"\"https://www.googleapis.com/compute/v1/projects/{project}/global/addresses/{address}\"", k, value))
+	return
+}
+
+var firestoreDatabaseIDRegex = regexp.MustCompile("^[a-z][a-z0-9-]{2,28}[a-z0-9]$")
+
+// validateFirestoreDatabaseID checks a Firestore database id, which is either
+// "(default)" or 4 to 30 lowercase letters, numbers and hyphens, starting with
+// a letter and not ending with a hyphen.
+func validateFirestoreDatabaseID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "(default)" || firestoreDatabaseIDRegex.MatchString(value) {
+		return
+	}
+	errors = append(errors, fmt.Errorf(
+		"%q (%q) must be \"(default)\", or 4 to 30 lowercase letters, numbers and hyphens, "+
+			"starting with a letter and not ending with a hyphen", k, value))
+	return
+}
+
+func validateFirestoreType(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"FIRESTORE_NATIVE", "DATASTORE_MODE"}, false)(v, k)
+}
+
+var dnsLabelRegex = regexp.MustCompile("^[a-zA-Z0-9](?:[-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?$")
+
+// validateDNSName checks a fully qualified DNS name, such as "example.com.",
+// whose labels are at most 63 characters and whose total length is at most
+// 253 characters. The trailing dot is only accepted, and then required, when
+// requireTrailingDot is set.
+func validateDNSName(requireTrailingDot bool) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		name := value
+		if requireTrailingDot {
+			if !strings.HasSuffix(name, ".") {
+				errors = append(errors, fmt.Errorf("%q (%q) must end with a trailing dot", k, value))
+				return
+			}
+			name = strings.TrimSuffix(name, ".")
+		}
+
+		if len(name) == 0 || len(name) > 253 {
+			errors = append(errors, fmt.Errorf("%q (%q) must be between 1 and 253 characters long", k, value))
+			return
+		}
+		for _, label := range strings.Split(name, ".") {
+			if !dnsLabelRegex.MatchString(label) {
+				errors = append(errors, fmt.Errorf(
+					"%q (%q) has an invalid label %q, labels must be 1 to 63 letters, numbers and hyphens, "+
+						"and can't start or end with a hyphen", k, value, label))
+				return
+			}
+		}
+		return
+	}
+}
+
+// validateManagedCertDomain checks a domain of a Google-managed SSL
+// certificate, which doesn't support wildcard domains.
+func validateManagedCertDomain(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.HasPrefix(value, "*.") {
+		errors = append(errors, fmt.Errorf("%q (%q) is a wildcard domain, which managed certificates don't support", k, value))
+		return
+	}
+	return validateDNSName(false)(v, k)
+}
+
+// validateFloatBetween checks that a value is a float64 between min and max,
+// inclusive. NaN is never in range.
+func validateFloatBetween(min, max float64) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value, ok := v.(float64)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %q to be float, got %T", k, v))
+			return
+		}
+		if math.IsNaN(value) || value < min || value > max {
+			errors = append(errors, fmt.Errorf("%q (%v) must be between %v and %v", k, value, min, max))
+		}
+		return
+	}
+}
+
+// validateCapacityScaler checks the capacity scaler of a backend service
+// backend, the fraction of its capacity to use.
+func validateCapacityScaler(v interface{}, k string) (ws []string, errors []error) {
+	return validateFloatBetween(0, 1)(v, k)
+}
+
+// validateHostPattern checks a URL map host rule host, which is "*", a host
+// name, or a host name with a single leading "*." wildcard.
+func validateHostPattern(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "*" {
+		return
+	}
+
+	host := strings.TrimPrefix(value, "*.")
+	if strings.Contains(host, "*") {
+		errors = append(errors, fmt.Errorf(
+			"%q (%q) can only use a wildcard as \"*\" or as a leading \"*.\", such as \"*.example.com\"", k, value))
+		return
+	}
+	return validateDNSName(false)(host, k)
+}
+
+func validatePasswordMinLength(v interface{}, k string) (ws []string, errors []error) {
+	return validateNonNegativeInt(v, k)
+}
+
+func validatePasswordComplexity(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"COMPLEXITY_UNSPECIFIED", "COMPLEXITY_DEFAULT"}, false)(v, k)
+}
+
+// validateUtilizationTarget checks an autoscaler utilization target, which
+// must be positive. CPU and load balancing targets are fractions of at most 1,
+// but custom metric targets can be any per-instance value, so a larger value
+// only warns.
+func validateUtilizationTarget(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(float64)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be float, got %T", k, v))
+		return
+	}
+	if math.IsNaN(value) || value <= 0 {
+		errors = append(errors, fmt.Errorf("%q (%v) must be greater than 0", k, value))
+		return
+	}
+	if value > 1 {
+		ws = append(ws, fmt.Sprintf("%q (%v) is greater than 1, which is only valid for custom metric targets, "+
+			"CPU and load balancing utilization targets must be at most 1", k, value))
+	}
+	return
+}
+
+// validateNetworkMTU checks the maximum transmission unit of a VPC network.
+func validateNetworkMTU(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 1300 || n > 8896 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 1300 and 8896", k, n))
+	}
+	return
+}
+
+func validateAdvertiseMode(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{"DEFAULT", "CUSTOM"}, false)(v, k)
+}
+
+// validateVPNSharedSecret warns when a VPN tunnel shared secret is weak: when
+// it is shorter than 16 characters, or only uses one kind of character, such
+// as only lowercase letters. It never errors, so that existing tunnels keep
+// working. As the secret is sensitive, warnings never include its value.
+func validateVPNSharedSecret(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 16 {
+		ws = append(ws, fmt.Sprintf("%q is shorter than 16 characters, consider using a longer, randomly generated secret", k))
+		return
+	}
+
+	classes := 0
+	for _, re := range []string{"[a-z]", "[A-Z]", "[0-9]", "[^a-zA-Z0-9]"} {
+		if regexp.MustCompile(re).MatchString(value) {
+			classes++
+		}
+	}
+	if classes < 2 {
+		ws = append(ws, fmt.Sprintf("%q only uses one kind of character, consider using a randomly generated secret "+
+			"mixing letters, numbers and symbols", k))
+	}
+	return
+}
+
+// validateRetryCount checks the number of times Cloud Scheduler retries a
+// failed job.
+func validateRetryCount(v interface{}, k string) (ws []string, errors []error) {
+	n, err := intValue(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+		return
+	}
+	if n < 0 || n > 5 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be between 0 and 5", k, n))
+	}
+	return
+}
+
+// validatePrefixLengthRange checks that a CIDR prefix length is between min
+// and max, inclusive. Ranges reserved for VPC peering must be /8 to /30.
+func validatePrefixLengthRange(min, max int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		n, err := intValue(v)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%q (%v) is not an integer: %s", k, v, err))
+			return
+		}
+		if n < min || n > max {
+			errors = append(errors, fmt.Errorf("%q (%d) must be a prefix length between /%d and /%d", k, n, min, max))
+		}
+		return
+	}
+}
+
+// validateSecurityPolicyAction checks the action of a Cloud Armor security
+// policy rule. Deny actions carry the HTTP status returned to the client.
+func validateSecurityPolicyAction(v interface{}, k string) (ws []string, errors []error) {
+	return validation.StringInSlice([]string{
+		"allow",
+		"deny(403)",
+		"deny(404)",
+		"deny(502)",
+		"redirect",
+		"throttle",
+		"rate_based_ban",
+	}, false)(v, k)
+}
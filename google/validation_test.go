@@ -3,6 +3,9 @@ package google
 import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"io/ioutil"
+	"math"
 	"regexp"
 	"strings"
 	"testing"
@@ -109,9 +112,19 @@ func TestValidateRFC1035Name(t *testing.T) {
 }
 
 type StringValidationTestCase struct {
-	TestName    string
-	Value       string
-	ExpectError bool
+	TestName      string
+	Value         string
+	ExpectError   bool
+	ExpectWarning bool
+}
+
+// ValidationTestCase is like StringValidationTestCase, for validators of fields
+// that aren't strings.
+type ValidationTestCase struct {
+	TestName      string
+	Value         interface{}
+	ExpectError   bool
+	ExpectWarning bool
 }
 
 type RFC1918NetworkTestCase struct {
@@ -132,13 +145,35 @@ func testStringValidationCases(cases []StringValidationTestCase, validationFunc
 }
 
 func testStringValidation(testCase StringValidationTestCase, validationFunc schema.SchemaValidateFunc) []error {
-	_, es := validationFunc(testCase.Value, testCase.TestName)
+	ws, errs := validationFunc(testCase.Value, testCase.TestName)
+	es := make([]error, 0)
+	if testCase.ExpectWarning != (len(ws) > 0) {
+		es = append(es, fmt.Errorf("Expected warning %t in case \"%s\" with string \"%s\", got %v", testCase.ExpectWarning, testCase.TestName, testCase.Value, ws))
+	}
 	if testCase.ExpectError {
-		if len(es) > 0 {
-			return nil
-		} else {
-			return []error{fmt.Errorf("Didn't see expected error in case \"%s\" with string \"%s\"", testCase.TestName, testCase.Value)}
+		if len(errs) == 0 {
+			es = append(es, fmt.Errorf("Didn't see expected error in case \"%s\" with string \"%s\"", testCase.TestName, testCase.Value))
+		}
+		return es
+	}
+
+	return append(es, errs...)
+}
+
+func testValidationCases(cases []ValidationTestCase, validationFunc schema.SchemaValidateFunc) []error {
+	es := make([]error, 0)
+	for _, c := range cases {
+		ws, errs := validationFunc(c.Value, c.TestName)
+		if c.ExpectWarning != (len(ws) > 0) {
+			es = append(es, fmt.Errorf("Expected warning %t in case \"%s\" with value %#v, got %v", c.ExpectWarning, c.TestName, c.Value, ws))
+		}
+		if c.ExpectError {
+			if len(errs) == 0 {
+				es = append(es, fmt.Errorf("Didn't see expected error in case \"%s\" with value %#v", c.TestName, c.Value))
+			}
+			continue
 		}
+		es = append(es, errs...)
 	}
 
 	return es
@@ -208,3 +243,2208 @@ func TestValidateCloudIoTID(t *testing.T) {
 		t.Errorf("Failed to validate CloudIoT ID names: %v", es)
 	}
 }
+
+func TestValidateGCSBucketName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-bucket"},
+		{TestName: "with underscores", Value: "my_bucket_123"},
+		{TestName: "with dots", Value: "www.example.com"},
+
+		// With errors
+		{TestName: "too short", Value: "ab", ExpectError: true},
+		{TestName: "uppercase", Value: "My-Bucket", ExpectError: true},
+		{TestName: "ends with a dash", Value: "my-bucket-", ExpectError: true},
+		{TestName: "too long without dots", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "long dot component", Value: strings.Repeat("a", 64) + ".com", ExpectError: true},
+		{TestName: "ip address", Value: "192.168.5.4", ExpectError: true},
+		{TestName: "goog prefix", Value: "google-bucket", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateGCSBucketName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate GCS bucket names: %v", es)
+	}
+}
+
+func TestValidateGCSURI(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "with object", Value: "gs://my-bucket/file"},
+		{TestName: "nested object", Value: "gs://my-bucket/path/to/file.txt"},
+		{TestName: "bucket only", Value: "gs://my-bucket"},
+
+		// With errors
+		{TestName: "http scheme", Value: "http://my-bucket/file", ExpectError: true},
+		{TestName: "no scheme", Value: "my-bucket/file", ExpectError: true},
+		{TestName: "invalid bucket", Value: "gs://My_Bucket-/file", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateGCSURI(false))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate GCS URIs: %v", es)
+	}
+
+	x = []StringValidationTestCase{
+		// No errors
+		{TestName: "with object", Value: "gs://my-bucket/file"},
+
+		// With errors
+		{TestName: "bucket only", Value: "gs://my-bucket", ExpectError: true},
+		{TestName: "trailing slash", Value: "gs://my-bucket/", ExpectError: true},
+		{TestName: "http scheme", Value: "http://my-bucket/file", ExpectError: true},
+	}
+
+	es = testStringValidationCases(x, validateGCSURI(true))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate GCS URIs requiring an object: %v", es)
+	}
+}
+
+func TestValidateIPProtocol(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "named", Value: "TCP"},
+		{TestName: "lowercase", Value: "tcp", ExpectWarning: true},
+		{TestName: "mixed case", Value: "Sctp", ExpectWarning: true},
+		{TestName: "zero", Value: "0"},
+		{TestName: "max number", Value: "255"},
+
+		// With errors
+		{TestName: "number too large", Value: "256", ExpectError: true},
+		{TestName: "negative number", Value: "-1", ExpectError: true},
+		{TestName: "unknown name", Value: "SMTP", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIPProtocol)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate IP protocols: %v", es)
+	}
+}
+
+func TestValidateMasterCIDR(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "valid 10.x", Value: "10.0.0.0/28"},
+		{TestName: "valid 172.x", Value: "172.16.0.32/28"},
+		{TestName: "valid 192.x", Value: "192.168.100.16/28"},
+
+		// With errors
+		{TestName: "not a CIDR", Value: "10.0.0.0", ExpectError: true},
+		{TestName: "wrong prefix length", Value: "10.0.0.0/24", ExpectError: true},
+		{TestName: "host bits set", Value: "10.0.0.1/28", ExpectError: true},
+		{TestName: "public range", Value: "8.8.8.0/28", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateMasterCIDR)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate master CIDRs: %v", es)
+	}
+}
+
+func TestValidateWithSentinels(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "sentinel", Value: "-"},
+		{TestName: "other sentinel", Value: "latest"},
+		{TestName: "valid inner value", Value: "foobar"},
+
+		// With errors
+		{TestName: "invalid inner value", Value: "Foo_Bar", ExpectError: true},
+		{TestName: "not quite a sentinel", Value: "--", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateWithSentinels([]string{"-", "latest"}, validateGCPName))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate values with sentinels: %v", es)
+	}
+}
+
+func TestValidateSQLTier(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "custom", Value: "db-custom-2-7680"},
+		{TestName: "standard", Value: "db-n1-standard-1"},
+		{TestName: "highmem", Value: "db-n1-highmem-8"},
+		{TestName: "shared core", Value: "db-f1-micro"},
+		{TestName: "legacy", Value: "D0"},
+		{TestName: "legacy larger", Value: "D16"},
+
+		// With errors
+		{TestName: "custom with bad memory", Value: "db-custom-2-7000", ExpectError: true},
+		{TestName: "custom missing memory", Value: "db-custom-2", ExpectError: true},
+		{TestName: "unknown legacy", Value: "D3", ExpectError: true},
+		{TestName: "missing prefix", Value: "n1-standard-1", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSQLTier)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate SQL tiers: %v", es)
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "seconds", Value: "60s"},
+		{TestName: "fractional seconds", Value: "1.5s"},
+		{TestName: "compound", Value: "1h30m"},
+
+		// With errors
+		{TestName: "no unit", Value: "60", ExpectError: true},
+		{TestName: "unknown unit", Value: "1d", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateDuration)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate durations: %v", es)
+	}
+}
+
+func TestValidateAlignmentPeriod(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "one minute", Value: "60s"},
+		{TestName: "five minutes", Value: "5m"},
+
+		// With errors
+		{TestName: "too short", Value: "30s", ExpectError: true},
+		{TestName: "fractional seconds", Value: "60.5s", ExpectError: true},
+		{TestName: "not a duration", Value: "sixty", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateAlignmentPeriod)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate alignment periods: %v", es)
+	}
+}
+
+func TestValidateComparisonOperator(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "greater than", Value: "COMPARISON_GT"},
+		{TestName: "not equal", Value: "COMPARISON_NE"},
+
+		// With errors
+		{TestName: "lowercase", Value: "comparison_gt", ExpectError: true},
+		{TestName: "unknown", Value: "COMPARISON_GTE", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateComparisonOperator)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate comparison operators: %v", es)
+	}
+}
+
+func TestValidateAckDeadline(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 10},
+		{TestName: "one minute", Value: 60},
+		{TestName: "maximum", Value: 600},
+		{TestName: "string", Value: "60"},
+
+		// With errors
+		{TestName: "too short", Value: 5, ExpectError: true},
+		{TestName: "too long", Value: 601, ExpectError: true},
+		{TestName: "string too long", Value: "601", ExpectError: true},
+		{TestName: "not a number", Value: "sixty", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateAckDeadline)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate ack deadlines: %v", es)
+	}
+}
+
+func TestValidateRetentionDays(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 1},
+		{TestName: "default", Value: 30},
+		{TestName: "maximum", Value: 3650},
+
+		// With errors
+		{TestName: "zero", Value: 0, ExpectError: true},
+		{TestName: "too long", Value: 3651, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateRetentionDays)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate retention days: %v", es)
+	}
+}
+
+func TestValidateWorkloadIdentityID(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-pool"},
+		{TestName: "minimum length", Value: "abcd"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 32)},
+		{TestName: "digits", Value: "pool-123"},
+
+		// With errors
+		{TestName: "gcp prefix", Value: "gcp-pool", ExpectError: true},
+		{TestName: "too short", Value: "abc", ExpectError: true},
+		{TestName: "too long", Value: strings.Repeat("a", 33), ExpectError: true},
+		{TestName: "uppercase", Value: "My-Pool", ExpectError: true},
+		{TestName: "underscore", Value: "my_pool", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateWorkloadIdentityPoolID)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Workload Identity pool ids: %v", es)
+	}
+
+	es = testStringValidationCases(x, validateWorkloadIdentityProviderID)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Workload Identity provider ids: %v", es)
+	}
+}
+
+func TestValidatePEM(t *testing.T) {
+	cert, err := ioutil.ReadFile("test-fixtures/ssl_cert/test.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ioutil.ReadFile("test-fixtures/ssl_cert/test.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := ioutil.ReadFile("test-fixtures/ssl_cert/test.csr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "certificate", Value: string(cert)},
+		{TestName: "chain", Value: string(cert) + string(cert)},
+
+		// With errors
+		{TestName: "private key", Value: string(key), ExpectError: true},
+		{TestName: "certificate request", Value: string(csr), ExpectError: true},
+		{TestName: "garbage", Value: "not a certificate", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validatePEMCertificate)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate PEM certificates: %v", es)
+	}
+
+	x = []StringValidationTestCase{
+		// No errors
+		{TestName: "private key", Value: string(key)},
+
+		// With errors
+		{TestName: "certificate", Value: string(cert), ExpectError: true},
+		{TestName: "garbage", Value: "not a key", ExpectError: true},
+	}
+
+	es = testStringValidationCases(x, validatePEMPrivateKey)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate PEM private keys: %v", es)
+	}
+}
+
+func TestValidateDiskType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "standard", Value: "pd-standard"},
+		{TestName: "extreme", Value: "pd-extreme"},
+		{TestName: "hyperdisk", Value: "hyperdisk-throughput"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/diskTypes/pd-ssd"},
+		{TestName: "partial link", Value: "zones/us-central1-a/diskTypes/pd-balanced"},
+
+		// With errors
+		{TestName: "regional only type", Value: "hyperdisk-balanced-high-availability", ExpectError: true},
+		{TestName: "regional link", Value: "projects/my-project/regions/us-central1/diskTypes/pd-ssd", ExpectError: true},
+		{TestName: "unknown", Value: "pd-fast", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateDiskType(false))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate zonal disk types: %v", es)
+	}
+
+	x = []StringValidationTestCase{
+		// No errors
+		{TestName: "standard", Value: "pd-standard"},
+		{TestName: "regional only type", Value: "hyperdisk-balanced-high-availability"},
+		{TestName: "regional link", Value: "projects/my-project/regions/us-central1/diskTypes/pd-ssd"},
+
+		// With errors
+		{TestName: "zonal only type", Value: "pd-extreme", ExpectError: true},
+		{TestName: "zonal link", Value: "projects/my-project/zones/us-central1-a/diskTypes/pd-ssd", ExpectError: true},
+	}
+
+	es = testStringValidationCases(x, validateDiskType(true))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate regional disk types: %v", es)
+	}
+}
+
+func TestValidateSAKeyAlgorithm(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "rsa 2048", Value: "KEY_ALG_RSA_2048"},
+		{TestName: "unspecified", Value: "KEY_ALG_UNSPECIFIED"},
+		{TestName: "rsa 1024", Value: "KEY_ALG_RSA_1024", ExpectWarning: true},
+
+		// With errors
+		{TestName: "rsa 4096", Value: "KEY_ALG_RSA_4096", ExpectError: true},
+		{TestName: "lowercase", Value: "key_alg_rsa_2048", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSAKeyAlgorithm)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate service account key algorithms: %v", es)
+	}
+}
+
+func TestValidateSAKeyType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "credentials file", Value: "TYPE_GOOGLE_CREDENTIALS_FILE"},
+		{TestName: "pkcs12", Value: "TYPE_PKCS12_FILE"},
+
+		// With errors
+		{TestName: "public key type", Value: "TYPE_X509_PEM_FILE", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSAKeyType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate service account key types: %v", es)
+	}
+}
+
+func TestValidateVersionedName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "latest", Value: "my-secret@latest"},
+		{TestName: "numbered", Value: "my-secret@3"},
+		{TestName: "no version", Value: "my-secret"},
+
+		// With errors
+		{TestName: "negative version", Value: "my-secret@-1", ExpectError: true},
+		{TestName: "zero version", Value: "my-secret@0", ExpectError: true},
+		{TestName: "unknown alias", Value: "my-secret@newest", ExpectError: true},
+		{TestName: "empty version", Value: "my-secret@", ExpectError: true},
+		{TestName: "invalid name", Value: "My_Secret@3", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateVersionedName(validateGCPName, "@"))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate versioned names: %v", es)
+	}
+
+	x = []StringValidationTestCase{
+		// No errors
+		{TestName: "colon separator", Value: "my-image:2"},
+
+		// With errors
+		{TestName: "wrong separator", Value: "my-image@2", ExpectError: true},
+	}
+
+	es = testStringValidationCases(x, validateVersionedName(validateGCPName, ":"))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate versioned names with a colon separator: %v", es)
+	}
+}
+
+func TestValidateBigtableInstanceType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "production", Value: "PRODUCTION"},
+		{TestName: "development", Value: "DEVELOPMENT"},
+
+		// With errors
+		{TestName: "lowercase", Value: "production", ExpectError: true},
+		{TestName: "unknown", Value: "STAGING", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateBigtableInstanceType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Bigtable instance types: %v", es)
+	}
+}
+
+func TestValidateComposerEnvName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-environment"},
+		{TestName: "single character", Value: "a"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 64)},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 65), ExpectError: true},
+		{TestName: "starts with a number", Value: "1-environment", ExpectError: true},
+		{TestName: "ends with a dash", Value: "environment-", ExpectError: true},
+		{TestName: "uppercase", Value: "Environment", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateComposerEnvName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Composer environment names: %v", es)
+	}
+}
+
+func TestValidateComposerImageVersion(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "full versions", Value: "composer-1.4.0-airflow-1.10.0"},
+		{TestName: "prefix versions", Value: "composer-1-airflow-1.10"},
+		{TestName: "latest", Value: "composer-latest-airflow-1.9"},
+		{TestName: "preview", Value: "composer-1.4.0-preview.2-airflow-1.10.0"},
+
+		// With errors
+		{TestName: "missing airflow", Value: "composer-1.4.0", ExpectError: true},
+		{TestName: "missing composer prefix", Value: "1.4.0-airflow-1.10.0", ExpectError: true},
+		{TestName: "partial composer version", Value: "composer-1.4-airflow-1.10.0", ExpectError: true},
+		{TestName: "trailing garbage", Value: "composer-1.4.0-airflow-1.10.0-x", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateComposerImageVersion)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Composer image versions: %v", es)
+	}
+}
+
+func TestValidateCloudRunServiceName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-service"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 63)},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "starts with a number", Value: "1service", ExpectError: true},
+		{TestName: "uppercase", Value: "MyService", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCloudRunServiceName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Cloud Run service names: %v", es)
+	}
+}
+
+func TestValidateContainerConcurrency(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "unlimited", Value: 0},
+		{TestName: "default", Value: 80},
+		{TestName: "maximum", Value: 1000},
+
+		// With errors
+		{TestName: "too large", Value: 1001, ExpectError: true},
+		{TestName: "negative", Value: -1, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateContainerConcurrency)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate container concurrency: %v", es)
+	}
+}
+
+func TestValidateVPCConnectorName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-connector"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 25)},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 26), ExpectError: true},
+		{TestName: "ends with a dash", Value: "connector-", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateVPCConnectorName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate VPC connector names: %v", es)
+	}
+}
+
+func TestValidateVPCConnectorMachineType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "e2-micro", Value: "e2-micro"},
+		{TestName: "e2-standard-4", Value: "e2-standard-4"},
+
+		// With errors
+		{TestName: "unsupported", Value: "n1-standard-1", ExpectError: true},
+		{TestName: "uppercase", Value: "E2-MICRO", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateVPCConnectorMachineType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate VPC connector machine types: %v", es)
+	}
+}
+
+func TestValidateNonNegativeInt(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "positive", Value: 3},
+		{TestName: "string", Value: "3"},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+		{TestName: "negative string", Value: "-1", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateNonNegativeInt)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate non-negative ints: %v", es)
+	}
+}
+
+func TestValidateManagedZoneName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-zone"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 63)},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "dots", Value: "example.com", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateManagedZoneName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate managed zone names: %v", es)
+	}
+}
+
+func TestValidateNotificationChannelType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "email", Value: "email"},
+		{TestName: "pagerduty", Value: "pagerduty"},
+
+		// With errors
+		{TestName: "uppercase", Value: "EMAIL", ExpectError: true},
+		{TestName: "unknown", Value: "fax", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNotificationChannelType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate notification channel types: %v", es)
+	}
+}
+
+func TestValidateRelativeResourceName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "projects/my-project/locations/us-central1/queues/my-queue"},
+
+		// With errors
+		{TestName: "empty segment", Value: "projects//locations/us-central1/queues/my-queue", ExpectError: true},
+		{TestName: "missing segment", Value: "projects/my-project/queues/my-queue", ExpectError: true},
+		{TestName: "extra segment", Value: "projects/my-project/locations/us-central1/queues/my-queue/tasks/1", ExpectError: true},
+		{TestName: "name only", Value: "my-queue", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateRelativeResourceName("projects/{project}/locations/{location}/queues/{queue}"))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate relative resource names: %v", es)
+	}
+}
+
+func TestValidateIAPBrandName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "brands/123456789"},
+
+		// With errors
+		{TestName: "non-numeric brand", Value: "brands/my-brand", ExpectError: true},
+		{TestName: "client name", Value: "brands/123456789/identityAwareProxyClients/abc", ExpectError: true},
+		{TestName: "missing prefix", Value: "123456789", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIAPBrandName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate IAP brand names: %v", es)
+	}
+}
+
+func TestValidateIAPClientName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "brands/123456789/identityAwareProxyClients/abc-123.apps.googleusercontent.com"},
+
+		// With errors
+		{TestName: "brand name", Value: "brands/123456789", ExpectError: true},
+		{TestName: "wrong collection", Value: "brands/123456789/clients/abc", ExpectError: true},
+		{TestName: "empty client", Value: "brands/123456789/identityAwareProxyClients/", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIAPClientName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate IAP client names: %v", es)
+	}
+}
+
+func TestValidateTasksQueueName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "projects/my-project/locations/us-central1/queues/my-queue"},
+
+		// With errors
+		{TestName: "name only", Value: "my-queue", ExpectError: true},
+		{TestName: "wrong collection", Value: "projects/my-project/locations/us-central1/topics/my-queue", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateTasksQueueName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Cloud Tasks queue names: %v", es)
+	}
+}
+
+func TestValidateMaxConcurrentDispatches(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 1},
+		{TestName: "maximum", Value: 5000},
+
+		// With errors
+		{TestName: "zero", Value: 0, ExpectError: true},
+		{TestName: "too large", Value: 5001, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateMaxConcurrentDispatches)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate max concurrent dispatches: %v", es)
+	}
+}
+
+func TestValidateFilestoreTier(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic hdd", Value: "BASIC_HDD"},
+		{TestName: "enterprise", Value: "ENTERPRISE"},
+
+		// With errors
+		{TestName: "lowercase", Value: "enterprise", ExpectError: true},
+		{TestName: "unknown", Value: "ULTRA", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateFilestoreTier)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Filestore tiers: %v", es)
+	}
+}
+
+func TestValidateRegexpSyntax(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "literal", Value: "master"},
+		{TestName: "anchored", Value: "^release-v[0-9]+$"},
+		{TestName: "group", Value: "(feature|fix)/.*"},
+
+		// With errors
+		{TestName: "unbalanced group", Value: "(feature|fix/.*", ExpectError: true},
+		{TestName: "unbalanced class", Value: "v[0-9", ExpectError: true},
+		{TestName: "bad repetition", Value: "*master", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateRegexpSyntax)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate regexp syntax: %v", es)
+	}
+
+	_, es = validateRegexpSyntax("(feature", "branch")
+	expected := "\"branch\" (\"(feature\") is not a valid regular expression: error parsing regexp: missing closing ): `(feature`"
+	if len(es) != 1 || es[0].Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, es)
+	}
+}
+
+func TestValidateIntMultipleOf(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "multiple", Value: 7680},
+		{TestName: "string multiple", Value: "512"},
+
+		// With errors
+		{TestName: "not a multiple", Value: 7000, ExpectError: true},
+		{TestName: "string not a multiple", Value: "300", ExpectError: true},
+		{TestName: "not a number", Value: "lots", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateIntMultipleOf(256))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate int multiples: %v", es)
+	}
+}
+
+func TestValidateIntMultipleOf_invalidStep(t *testing.T) {
+	for _, step := range []int{0, -256} {
+		x := []ValidationTestCase{
+			{TestName: "zero", Value: 0, ExpectError: true},
+			{TestName: "multiple", Value: 512, ExpectError: true},
+		}
+
+		es := testValidationCases(x, validateIntMultipleOf(step))
+		if len(es) > 0 {
+			t.Errorf("Failed to reject step %d: %v", step, es)
+		}
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "in range multiple", Value: 1000},
+
+		// With errors
+		{TestName: "in range, not a multiple", Value: 1050, ExpectError: true},
+		{TestName: "out of range multiple", Value: 5000, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateAll(validation.IntBetween(100, 2000), validateIntMultipleOf(100)))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate with composed validators: %v", es)
+	}
+
+	_, errs := validateAll(validation.IntBetween(100, 2000), validateIntMultipleOf(100))(5050, "processing_units")
+	if len(errs) != 2 {
+		t.Errorf("Expected errors from both validators, got %v", errs)
+	}
+}
+
+func TestValidateRegion(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "us-central1", Value: "us-central1"},
+		{TestName: "multiple words", Value: "northamerica-northeast1"},
+
+		// With errors
+		{TestName: "zone", Value: "us-central1-a", ExpectError: true},
+		{TestName: "multi-region", Value: "us", ExpectError: true},
+		{TestName: "uppercase", Value: "US-CENTRAL1", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateRegion)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate regions: %v", es)
+	}
+}
+
+func TestValidateGCRLocation(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "eu", Value: "eu"},
+		{TestName: "us", Value: "us"},
+		{TestName: "global", Value: ""},
+
+		// With errors
+		{TestName: "region", Value: "us-central1", ExpectError: true},
+		{TestName: "artifact registry multi-region", Value: "europe", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateGCRLocation)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate GCR locations: %v", es)
+	}
+}
+
+func TestValidateArtifactRegistryLocation(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "region", Value: "us-central1"},
+		{TestName: "multi-region", Value: "europe"},
+
+		// With errors
+		{TestName: "gcr multi-region", Value: "eu", ExpectError: true},
+		{TestName: "zone", Value: "us-central1-a", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateArtifactRegistryLocation)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Artifact Registry locations: %v", es)
+	}
+}
+
+func TestValidateSQLFlagName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "mysql flag", Value: "slow_query_log"},
+		{TestName: "postgres extension flag", Value: "cloudsql.iam_authentication"},
+
+		// With errors
+		{TestName: "uppercase", Value: "SLOW_QUERY_LOG", ExpectError: true},
+		{TestName: "dashes", Value: "slow-query-log", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSQLFlagName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate SQL flag names: %v", es)
+	}
+}
+
+func TestValidateRegionInList(t *testing.T) {
+	regions := []string{"us-central1", "us-east1", "europe-west1", "asia-east1"}
+	f := validateRegionInList(regions)
+
+	if _, es := f("us-central1", "region"); len(es) > 0 {
+		t.Errorf("Expected no errors for a known region, got %v", es)
+	}
+
+	_, es := f("us-central-1", "region")
+	if len(es) != 1 || !strings.Contains(es[0].Error(), "did you mean us-central1?") {
+		t.Errorf("Expected a suggestion for a typo, got %v", es)
+	}
+
+	_, es = f("antarctica-south7", "region")
+	if len(es) != 1 || strings.Contains(es[0].Error(), "did you mean") {
+		t.Errorf("Expected an error without a suggestion for an unknown region, got %v", es)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"us-central1", "us-central1", 0},
+		{"us-central-1", "us-central1", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, c := range cases {
+		if d := levenshteinDistance(c.a, c.b); d != c.distance {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", c.a, c.b, d, c.distance)
+		}
+	}
+}
+
+func TestValidateNotAlreadyBase64(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No warnings
+		{TestName: "password", Value: "correct horse battery staple"},
+		{TestName: "alphanumeric password", Value: "MySecretPassword"},
+		{TestName: "random alphanumeric password", Value: "Xk9mP2qR7vL4nB8w"},
+		{TestName: "short password with slash", Value: "hunter2/hunter2h"},
+		{TestName: "short base64", Value: "AAECAw=="},
+		{TestName: "base64 of text", Value: "aGVsbG8gd29ybGQgaGVsbG8gd29ybGQ="},
+		{TestName: "empty", Value: ""},
+
+		// With warnings
+		{TestName: "base64 of binary", Value: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=", ExpectWarning: true},
+		{TestName: "unpadded base64 of binary", Value: "//79/Pv6+fj39vX08/Lx8O/u7ezr6uno", ExpectWarning: true},
+	}
+
+	es := testStringValidationCases(x, validateNotAlreadyBase64)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate base64 values: %v", es)
+	}
+}
+
+func TestValidateSelfLink(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "v1", Value: "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/default"},
+		{TestName: "beta", Value: "https://www.googleapis.com/compute/beta/projects/my-project/zones/us-central1-a/instances/vm"},
+
+		// With errors
+		{TestName: "relative name", Value: "projects/my-project/global/networks/default", ExpectError: true},
+		{TestName: "http", Value: "http://www.googleapis.com/compute/v1/projects/my-project/global/networks/default", ExpectError: true},
+		{TestName: "trailing slash", Value: "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/", ExpectError: true},
+		{TestName: "other host", Value: "https://example.com/compute/v1/projects/my-project/global/networks/default", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSelfLink)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate self links: %v", es)
+	}
+}
+
+func TestValidateMachineImageName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-machine-image"},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "uppercase", Value: "My-Image", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateMachineImageName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate machine image names: %v", es)
+	}
+}
+
+func TestValidateMachineImageSourceInstance(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "relative name", Value: "projects/my-project/zones/us-central1-a/instances/my-instance"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance"},
+
+		// With errors
+		{TestName: "name only", Value: "my-instance", ExpectError: true},
+		{TestName: "wrong collection", Value: "projects/my-project/zones/us-central1-a/disks/my-disk", ExpectError: true},
+		{TestName: "self link to wrong collection", Value: "https://www.googleapis.com/compute/v1/projects/my-project/global/images/my-image", ExpectError: true},
+		{TestName: "malformed self link", Value: "https://www.googleapis.com/projects/my-project/zones/us-central1-a/instances/my-instance", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateMachineImageSourceInstance)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate machine image source instances: %v", es)
+	}
+}
+
+func TestValidateFunctionMemoryMB(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "default", Value: 256},
+		{TestName: "largest", Value: 8192},
+		{TestName: "string", Value: "512"},
+
+		// With errors
+		{TestName: "not an allowed size", Value: 300, ExpectError: true},
+		{TestName: "zero", Value: 0, ExpectError: true},
+		{TestName: "quantity string", Value: "512M", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateFunctionMemoryMB)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate function memory: %v", es)
+	}
+}
+
+func TestValidateNetworkName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-network"},
+		{TestName: "single character", Value: "a"},
+		{TestName: "two characters", Value: "a1"},
+		{TestName: "maximum length", Value: strings.Repeat("a", 63)},
+
+		// With errors
+		{TestName: "too long", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "ends with a dash", Value: "network-", ExpectError: true},
+		{TestName: "starts with a number", Value: "1network", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNetworkName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate network names: %v", es)
+	}
+}
+
+func TestValidateZone(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "us-central1-a", Value: "us-central1-a"},
+		{TestName: "multiple words", Value: "northamerica-northeast1-c"},
+
+		// With errors
+		{TestName: "region", Value: "us-central1", ExpectError: true},
+		{TestName: "uppercase", Value: "US-CENTRAL1-A", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateZone)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate zones: %v", es)
+	}
+}
+
+func TestValidateBackendBucketName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-static-assets"},
+
+		// With errors
+		{TestName: "uppercase", Value: "My-Static-Assets", ExpectError: true},
+		{TestName: "gs uri", Value: "gs://my-static-assets", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateBackendBucketName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate backend bucket names: %v", es)
+	}
+}
+
+func TestValidateCDNCacheMode(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "cache all static", Value: "CACHE_ALL_STATIC"},
+		{TestName: "origin headers", Value: "USE_ORIGIN_HEADERS"},
+
+		// With errors
+		{TestName: "unknown", Value: "CACHE_NOTHING", ExpectError: true},
+		{TestName: "lowercase", Value: "force_cache_all", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCDNCacheMode)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate CDN cache modes: %v", es)
+	}
+}
+
+func TestValidateSSLPolicyProfile(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "modern", Value: "MODERN"},
+		{TestName: "custom", Value: "CUSTOM"},
+
+		// With errors
+		{TestName: "unknown", Value: "STRICT", ExpectError: true},
+		{TestName: "lowercase", Value: "modern", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSSLPolicyProfile)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate SSL policy profiles: %v", es)
+	}
+}
+
+func TestValidateCronSchedule(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "every minute", Value: "* * * * *"},
+		{TestName: "weekdays", Value: "0 9 * * MON-FRI"},
+		{TestName: "steps and lists", Value: "*/15 0,12 1-15 JAN,jul 0"},
+		{TestName: "sunday as 7", Value: "30 2 * * 7"},
+
+		// With errors
+		{TestName: "too few fields", Value: "0 9 * *", ExpectError: true},
+		{TestName: "too many fields", Value: "0 0 9 * * *", ExpectError: true},
+		{TestName: "minute out of range", Value: "60 9 * * *", ExpectError: true},
+		{TestName: "day of month zero", Value: "0 9 0 * *", ExpectError: true},
+		{TestName: "unknown name", Value: "0 9 * * FUN", ExpectError: true},
+		{TestName: "bad step", Value: "*/x 9 * * *", ExpectError: true},
+		{TestName: "app engine syntax", Value: "every 24 hours", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCronSchedule)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate cron schedules: %v", es)
+	}
+}
+
+func TestValidateTimeZone(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "utc", Value: "UTC"},
+		{TestName: "region and city", Value: "America/New_York"},
+		{TestName: "nested", Value: "America/Argentina/Buenos_Aires"},
+		{TestName: "hyphenated", Value: "America/Port-au-Prince"},
+		{TestName: "offset", Value: "Etc/GMT+5"},
+
+		// With errors
+		{TestName: "space", Value: "America/New York", ExpectError: true},
+		{TestName: "empty segment", Value: "America//New_York", ExpectError: true},
+		{TestName: "utc offset", Value: "+05:00", ExpectError: true},
+		{TestName: "local", Value: "Local", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateTimeZone)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate time zones: %v", es)
+	}
+}
+
+func TestValidateEnumWithDeprecations(t *testing.T) {
+	f := validateEnumWithDeprecations([]string{"STANDARD", "PREMIUM"}, map[string]string{
+		"LEGACY": "use STANDARD instead",
+	})
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "current", Value: "PREMIUM"},
+		{TestName: "deprecated", Value: "LEGACY", ExpectWarning: true},
+
+		// With errors
+		{TestName: "unknown", Value: "BASIC", ExpectError: true},
+		{TestName: "wrong case", Value: "legacy", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, f)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate enum with deprecations: %v", es)
+	}
+}
+
+func TestValidateCIDRNotDefaultRoute(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "private range", Value: "10.0.0.0/8"},
+		{TestName: "ipv6 range", Value: "2001:db8::/32"},
+
+		// With errors
+		{TestName: "ipv4 default", Value: "0.0.0.0/0", ExpectError: true},
+		{TestName: "ipv6 default", Value: "::/0", ExpectError: true},
+		{TestName: "not a cidr", Value: "10.0.0.0", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCIDRNotDefaultRoute)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate non default route CIDRs: %v", es)
+	}
+}
+
+func TestValidateSelfLinkScope(t *testing.T) {
+	global := "https://www.googleapis.com/compute/v1/projects/my-project/global/backendServices/my-backend"
+	regional := "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/backendServices/my-backend"
+	zonal := "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance"
+
+	cases := map[string][]StringValidationTestCase{
+		"global": {
+			{TestName: "global", Value: global},
+			{TestName: "regional", Value: regional, ExpectError: true},
+			{TestName: "zonal", Value: zonal, ExpectError: true},
+		},
+		"regional": {
+			{TestName: "regional", Value: regional},
+			{TestName: "global", Value: global, ExpectError: true},
+		},
+		"zonal": {
+			{TestName: "zonal", Value: zonal},
+			{TestName: "regional", Value: regional, ExpectError: true},
+		},
+		"unknown": {
+			{TestName: "global", Value: global, ExpectError: true},
+		},
+	}
+
+	for scope, x := range cases {
+		es := testStringValidationCases(x, validateSelfLinkScope(scope))
+		if len(es) > 0 {
+			t.Errorf("Failed to validate %s self links: %v", scope, es)
+		}
+	}
+}
+
+func TestValidateEnableServiceName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "compute", Value: "compute.googleapis.com"},
+		{TestName: "hyphenated", Value: "dataproc-control.googleapis.com"},
+		{TestName: "renamed", Value: "bigquery-json.googleapis.com", ExpectWarning: true},
+
+		// With errors
+		{TestName: "no domain", Value: "compute", ExpectError: true},
+		{TestName: "other domain", Value: "endpoints.example.com", ExpectError: true},
+		{TestName: "uppercase", Value: "Compute.googleapis.com", ExpectError: true},
+		{TestName: "url", Value: "https://compute.googleapis.com", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateEnableServiceName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate service names: %v", es)
+	}
+}
+
+func TestValidateClusterName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-cluster"},
+		{TestName: "40 characters", Value: strings.Repeat("a", 40)},
+
+		// With errors
+		{TestName: "41 characters", Value: strings.Repeat("a", 41), ExpectError: true},
+		{TestName: "uppercase", Value: "My-cluster", ExpectError: true},
+		{TestName: "starts with a number", Value: "1-cluster", ExpectError: true},
+		{TestName: "ends with a hyphen", Value: "my-cluster-", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateClusterName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate cluster names: %v", es)
+	}
+}
+
+func TestValidatePublicCIDR(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "public range", Value: "203.0.113.0/24"},
+		{TestName: "public address", Value: "198.51.100.7"},
+
+		// With errors
+		{TestName: "10/8", Value: "10.0.0.0/8", ExpectError: true},
+		{TestName: "within 172.16/12", Value: "172.20.0.0/16", ExpectError: true},
+		{TestName: "private address", Value: "192.168.1.10", ExpectError: true},
+		{TestName: "invalid", Value: "not-a-cidr", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validatePublicCIDR)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate public CIDRs: %v", es)
+	}
+
+	x = []StringValidationTestCase{
+		{TestName: "public range", Value: "203.0.113.0/24"},
+		{TestName: "10/8", Value: "10.0.0.0/8", ExpectWarning: true},
+		{TestName: "invalid", Value: "not-a-cidr", ExpectError: true},
+	}
+
+	es = testStringValidationCases(x, publicCIDRValidator(true))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate public CIDRs with warnings: %v", es)
+	}
+}
+
+func TestValidateNEGType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "vm ip port", Value: "GCE_VM_IP_PORT"},
+		{TestName: "serverless", Value: "SERVERLESS"},
+		{TestName: "internet fqdn port", Value: "INTERNET_FQDN_PORT"},
+
+		// With errors
+		{TestName: "lowercase", Value: "serverless", ExpectError: true},
+		{TestName: "unknown", Value: "GCE_VM_PORT", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNEGType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate NEG types: %v", es)
+	}
+}
+
+func TestValidateIndexDirection(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "ascending", Value: "ASCENDING"},
+		{TestName: "descending", Value: "DESCENDING"},
+		{TestName: "lowercase", Value: "ascending", ExpectWarning: true},
+
+		// With errors
+		{TestName: "abbreviated", Value: "ASC", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIndexDirection)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate index directions: %v", es)
+	}
+}
+
+func TestValidateArrayConfig(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "contains", Value: "CONTAINS"},
+		{TestName: "lowercase", Value: "contains", ExpectWarning: true},
+
+		// With errors
+		{TestName: "unknown", Value: "CONTAINS_ANY", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateArrayConfig)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate array configs: %v", es)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "https", Value: "https://example.com/path"},
+		{TestName: "http", Value: "http://example.com:8080"},
+
+		// With errors
+		{TestName: "other scheme", Value: "ftp://example.com", ExpectError: true},
+		{TestName: "no scheme", Value: "example.com/path", ExpectError: true},
+		{TestName: "no host", Value: "https:///path", ExpectError: true},
+		{TestName: "unparseable", Value: "https://exa mple.com/%zz", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateURL([]string{"http", "https"}))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate URLs: %v", es)
+	}
+}
+
+func TestValidatePushEndpoint(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "https", Value: "https://example.com/push"},
+
+		// With errors
+		{TestName: "http", Value: "http://example.com/push", ExpectError: true},
+		{TestName: "no scheme", Value: "example.com/push", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validatePushEndpoint)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate push endpoints: %v", es)
+	}
+}
+
+func TestValidateShareType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "local", Value: "LOCAL"},
+		{TestName: "specific projects", Value: "SPECIFIC_PROJECTS"},
+
+		// With errors
+		{TestName: "lowercase", Value: "local", ExpectError: true},
+		{TestName: "organization", Value: "ORGANIZATION", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateShareType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate share types: %v", es)
+	}
+}
+
+func TestValidateNamePrefix(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "short", Value: "tf-test-"},
+		{TestName: "37 characters", Value: "a" + strings.Repeat("-", 36)},
+
+		// With errors
+		{TestName: "38 characters", Value: strings.Repeat("a", 38), ExpectError: true},
+		{TestName: "uppercase", Value: "TF-test-", ExpectError: true},
+		{TestName: "starts with a hyphen", Value: "-tf-test", ExpectError: true},
+		{TestName: "underscore", Value: "tf_test", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNamePrefix(37))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate name prefixes: %v", es)
+	}
+}
+
+func TestValidateMetadataKey(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "custom", Value: "my-key"},
+		{TestName: "underscores", Value: "enable_oslogin"},
+		{TestName: "startup script", Value: "startup-script", ExpectWarning: true},
+		{TestName: "container declaration", Value: "gce-container-declaration", ExpectWarning: true},
+
+		// With errors
+		{TestName: "empty", Value: "", ExpectError: true},
+		{TestName: "dot", Value: "my.key", ExpectError: true},
+		{TestName: "too long", Value: strings.Repeat("a", 129), ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateMetadataKey)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate metadata keys: %v", es)
+	}
+}
+
+func TestValidateBackupRetentionCount(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 1},
+		{TestName: "maximum", Value: 365},
+		{TestName: "string", Value: "7"},
+
+		// With errors
+		{TestName: "zero", Value: 0, ExpectError: true},
+		{TestName: "too many", Value: 366, ExpectError: true},
+		{TestName: "not a number", Value: "seven", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateBackupRetentionCount)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate backup retention counts: %v", es)
+	}
+}
+
+func TestValidateCAPoolTier(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "enterprise", Value: "ENTERPRISE"},
+		{TestName: "devops", Value: "DEVOPS"},
+
+		// With errors
+		{TestName: "lowercase", Value: "devops", ExpectError: true},
+		{TestName: "unknown", Value: "STANDARD", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCAPoolTier)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate CA pool tiers: %v", es)
+	}
+}
+
+func TestValidateCertificateLifetime(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "30 days", Value: "2592000s"},
+		{TestName: "hours", Value: "720h"},
+		{TestName: "10 years", Value: "315360000s"},
+
+		// With errors
+		{TestName: "over 10 years", Value: "315360001s", ExpectError: true},
+		{TestName: "zero", Value: "0s", ExpectError: true},
+		{TestName: "no unit", Value: "2592000", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateCertificateLifetime)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate certificate lifetimes: %v", es)
+	}
+}
+
+func TestValidateDataprocClusterName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-cluster"},
+		{TestName: "single letter", Value: "a"},
+		{TestName: "52 characters", Value: strings.Repeat("a", 52)},
+
+		// With errors
+		{TestName: "53 characters", Value: strings.Repeat("a", 53), ExpectError: true},
+		{TestName: "uppercase", Value: "My-cluster", ExpectError: true},
+		{TestName: "ends with a hyphen", Value: "my-cluster-", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateDataprocClusterName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Dataproc cluster names: %v", es)
+	}
+}
+
+func TestValidateVertexRegion(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "us-central1", Value: "us-central1"},
+		{TestName: "europe-west4", Value: "europe-west4"},
+
+		// With errors
+		{TestName: "typo", Value: "us-centrall", ExpectError: true},
+		{TestName: "unsupported", Value: "southamerica-east1", ExpectError: true},
+		{TestName: "zone", Value: "us-central1-a", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateVertexRegion)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Vertex AI regions: %v", es)
+	}
+
+	_, es = validateVertexRegion("us-centrall", "region")
+	expected := "\"region\" (\"us-centrall\") is not a region Vertex AI is available in, did you mean us-central1? " +
+		"Supported regions include us-central1, europe-west4 and asia-east1"
+	if len(es) != 1 || es[0].Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, es)
+	}
+
+	_, es = validateVertexRegion("southamerica-east1", "region")
+	expected = "\"region\" (\"southamerica-east1\") is not a region Vertex AI is available in. " +
+		"Supported regions include us-central1, europe-west4 and asia-east1"
+	if len(es) != 1 || es[0].Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, es)
+	}
+}
+
+func TestValidateVertexResourceName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "dataset", Value: "projects/my-project/locations/us-central1/datasets/123"},
+		{TestName: "endpoint", Value: "projects/my-project/locations/europe-west4/endpoints/my-endpoint"},
+
+		// With errors
+		{TestName: "missing location", Value: "projects/my-project/datasets/123", ExpectError: true},
+		{TestName: "trailing slash", Value: "projects/my-project/locations/us-central1/datasets/", ExpectError: true},
+		{TestName: "bare id", Value: "123", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateVertexResourceName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Vertex AI resource names: %v", es)
+	}
+}
+
+func TestValidateComputeImage(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "family", Value: "family/debian-12"},
+		{TestName: "project family", Value: "projects/debian-cloud/global/images/family/debian-12"},
+		{TestName: "project image", Value: "projects/debian-cloud/global/images/debian-12-bookworm"},
+		{TestName: "shorthand", Value: "debian-cloud/debian-12"},
+		{TestName: "name", Value: "debian-12"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/debian-cloud/global/images/debian-12-bookworm"},
+		{TestName: "dated name", Value: "debian-12-bookworm-v20240110", ExpectWarning: true},
+		{TestName: "dated project image", Value: "projects/debian-cloud/global/images/debian-9-stretch-v20180105", ExpectWarning: true},
+
+		// With errors
+		{TestName: "empty", Value: "", ExpectError: true},
+		{TestName: "zonal path", Value: "projects/debian-cloud/zones/us-central1-a/images/debian-12", ExpectError: true},
+		{TestName: "invalid characters", Value: "debian 12", ExpectError: true},
+		{TestName: "other api", Value: "https://example.com/images/debian-12", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateComputeImage)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate compute images: %v", es)
+	}
+}
+
+func TestValidateLogFilter(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "simple", Value: `resource.type = "gce_instance"`},
+		{TestName: "grouped", Value: `(severity >= ERROR OR logName:"syslog") AND NOT resource.type = "gae_app"`},
+		{TestName: "parenthesis in string", Value: `textPayload:"(unbalanced"`},
+		{TestName: "escaped quote", Value: `textPayload:"say \"hi\""`},
+		{TestName: "empty", Value: "", ExpectWarning: true},
+		{TestName: "blank", Value: "  ", ExpectWarning: true},
+
+		// With errors
+		{TestName: "unclosed parenthesis", Value: `(severity >= ERROR`, ExpectError: true},
+		{TestName: "extra parenthesis", Value: `severity >= ERROR)`, ExpectError: true},
+		{TestName: "unterminated string", Value: `resource.type = "gce_instance`, ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateLogFilter)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate log filters: %v", es)
+	}
+}
+
+func TestValidateOrgPolicyConstraint(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "managed", Value: "constraints/compute.disableSerialPortAccess"},
+		{TestName: "gcp", Value: "constraints/gcp.resourceLocations"},
+		{TestName: "custom", Value: "customConstraints/custom.denyPublicBuckets"},
+
+		// With errors
+		{TestName: "bare", Value: "compute.disableSerialPortAccess", ExpectError: true},
+		{TestName: "no service", Value: "constraints/disableSerialPortAccess", ExpectError: true},
+		{TestName: "custom without prefix", Value: "customConstraints/denyPublicBuckets", ExpectError: true},
+		{TestName: "custom with hyphen", Value: "customConstraints/custom.deny-public", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateOrgPolicyConstraint)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate org policy constraints: %v", es)
+	}
+}
+
+func TestValidateTargetSize(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "positive", Value: 3},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateTargetSize)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate target sizes: %v", es)
+	}
+}
+
+func TestValidateServiceAccountAccountID(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "8 characters", Value: "my-robot"},
+		{TestName: "6 characters", Value: "robot1"},
+		{TestName: "30 characters", Value: strings.Repeat("a", 30)},
+
+		// With errors
+		{TestName: "5 characters", Value: "robot", ExpectError: true},
+		{TestName: "31 characters", Value: strings.Repeat("a", 31), ExpectError: true},
+		{TestName: "starts with a number", Value: "1-robot", ExpectError: true},
+		{TestName: "ends with a hyphen", Value: "my-robot-", ExpectError: true},
+		{TestName: "email", Value: "my-robot@my-project.iam.gserviceaccount.com", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateServiceAccountAccountID)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate service account ids: %v", es)
+	}
+}
+
+func TestValidateSQLMasterInstanceName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "name", Value: "my-master"},
+		{TestName: "project and name", Value: "my-project:my-master"},
+		{TestName: "self link", Value: "https://www.googleapis.com/sql/v1beta4/projects/my-project/instances/my-master"},
+
+		// With errors
+		{TestName: "uppercase", Value: "My-master", ExpectError: true},
+		{TestName: "ends with a hyphen", Value: "my-master-", ExpectError: true},
+		{TestName: "compute link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/instances/my-master", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSQLMasterInstanceName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate SQL master instance names: %v", es)
+	}
+}
+
+func TestValidateFirewallLogMetadata(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "exclude", Value: "EXCLUDE_ALL_METADATA"},
+		{TestName: "include", Value: "INCLUDE_ALL_METADATA"},
+
+		// With errors
+		{TestName: "lowercase", Value: "include_all_metadata", ExpectError: true},
+		{TestName: "custom", Value: "CUSTOM_METADATA", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateFirewallLogMetadata)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate firewall log metadata: %v", es)
+	}
+}
+
+func TestValidateNATSourceSubnetworkMode(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "all ranges", Value: "ALL_SUBNETWORKS_ALL_IP_RANGES"},
+		{TestName: "primary ranges", Value: "ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES"},
+		{TestName: "list", Value: "LIST_OF_SUBNETWORKS"},
+
+		// With errors
+		{TestName: "lowercase", Value: "list_of_subnetworks", ExpectError: true},
+		{TestName: "unknown", Value: "ALL_SUBNETWORKS", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNATSourceSubnetworkMode)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate NAT source subnetwork modes: %v", es)
+	}
+}
+
+func TestValidateNameServerIP(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "private", Value: "10.0.0.2"},
+		{TestName: "public", Value: "8.8.8.8"},
+		{TestName: "ipv6", Value: "2001:4860:4860::8888"},
+
+		// With errors
+		{TestName: "loopback", Value: "127.0.0.1", ExpectError: true},
+		{TestName: "ipv6 loopback", Value: "::1", ExpectError: true},
+		{TestName: "multicast", Value: "224.0.0.251", ExpectError: true},
+		{TestName: "hostname", Value: "ns1.example.com", ExpectError: true},
+		{TestName: "cidr", Value: "10.0.0.2/32", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateNameServerIP)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate name server IPs: %v", es)
+	}
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "basic", Value: "my-snapshot"},
+		{TestName: "63 characters", Value: strings.Repeat("a", 63)},
+
+		// With errors
+		{TestName: "64 characters", Value: strings.Repeat("a", 64), ExpectError: true},
+		{TestName: "uppercase", Value: "My-snapshot", ExpectError: true},
+		{TestName: "ends with a hyphen", Value: "my-snapshot-", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSnapshotName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate snapshot names: %v", es)
+	}
+}
+
+func TestValidateSnapshotSourceDisk(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "zonal relative name", Value: "projects/my-project/zones/us-central1-a/disks/my-disk"},
+		{TestName: "regional relative name", Value: "projects/my-project/regions/us-central1/disks/my-disk"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/disks/my-disk"},
+
+		// With errors
+		{TestName: "instance", Value: "projects/my-project/zones/us-central1-a/instances/my-instance", ExpectError: true},
+		{TestName: "global", Value: "projects/my-project/global/disks/my-disk", ExpectError: true},
+		{TestName: "other api", Value: "https://example.com/projects/my-project/zones/us-central1-a/disks/my-disk", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSnapshotSourceDisk)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate snapshot source disks: %v", es)
+	}
+}
+
+func TestValidateKMSImportMethod(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "sha1", Value: "RSA_OAEP_3072_SHA1_AES_256"},
+		{TestName: "sha256", Value: "RSA_OAEP_4096_SHA256"},
+
+		// With errors
+		{TestName: "lowercase", Value: "rsa_oaep_3072_sha1_aes_256", ExpectError: true},
+		{TestName: "unknown key size", Value: "RSA_OAEP_2048_SHA256", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateKMSImportMethod)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate KMS import methods: %v", es)
+	}
+}
+
+func TestValidateKMSProtectionLevel(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "software", Value: "SOFTWARE"},
+		{TestName: "hsm", Value: "HSM"},
+		{TestName: "external vpc", Value: "EXTERNAL_VPC"},
+
+		// With errors
+		{TestName: "lowercase", Value: "hsm", ExpectError: true},
+		{TestName: "unknown", Value: "CLOUD_HSM", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateKMSProtectionLevel)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate KMS protection levels: %v", es)
+	}
+}
+
+func TestValidateMaxInstanceCount(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "maximum", Value: 1000},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+		{TestName: "over quota", Value: 1001, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateMaxInstanceCount)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate max instance counts: %v", es)
+	}
+}
+
+func TestValidateReleaseChannel(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "regular", Value: "REGULAR"},
+		{TestName: "unspecified", Value: "UNSPECIFIED"},
+
+		// With errors
+		{TestName: "lowercase", Value: "stable", ExpectError: true},
+		{TestName: "unknown", Value: "EXTENDED_STABLE", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateReleaseChannel)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate release channels: %v", es)
+	}
+}
+
+func TestValidateTargetPoolSessionAffinity(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "none", Value: "NONE"},
+		{TestName: "client ip", Value: "CLIENT_IP"},
+		{TestName: "client ip proto", Value: "CLIENT_IP_PROTO"},
+		{TestName: "lowercase", Value: "client_ip", ExpectWarning: true},
+
+		// With errors
+		{TestName: "backend service affinity", Value: "GENERATED_COOKIE", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateTargetPoolSessionAffinity)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate target pool session affinities: %v", es)
+	}
+}
+
+func TestValidateBigQuerySchedule(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "hours", Value: "every 6 hours"},
+		{TestName: "minutes with range", Value: "every 15 minutes from 09:00 to 17:00"},
+		{TestName: "day", Value: "every day 00:00"},
+		{TestName: "weekdays", Value: "every mon,wed,fri 09:30"},
+		{TestName: "ordinal", Value: "first sunday of sep 00:00"},
+		{TestName: "ordinals of month", Value: "1st,3rd monday of month 09:00"},
+		{TestName: "mixed case", Value: "Every 24 Hours"},
+
+		// With errors
+		{TestName: "unix cron", Value: "0 9 * * 1", ExpectError: true},
+		{TestName: "zero interval", Value: "every 0 hours", ExpectError: true},
+		{TestName: "missing unit", Value: "every 6", ExpectError: true},
+		{TestName: "unknown unit", Value: "every 6 weeks", ExpectError: true},
+		{TestName: "invalid time", Value: "every day 24:00", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateBigQuerySchedule)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate BigQuery schedules: %v", es)
+	}
+}
+
+func TestValidateIPVersion(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "ipv4", Value: "IPV4"},
+		{TestName: "ipv6", Value: "IPV6"},
+		{TestName: "empty", Value: ""},
+
+		// With errors
+		{TestName: "lowercase", Value: "ipv6", ExpectError: true},
+		{TestName: "unknown", Value: "IPV5", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateIPVersion)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate IP versions: %v", es)
+	}
+}
+
+func TestValidateRoutePriority(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "default", Value: 1000},
+		{TestName: "maximum", Value: 65535},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+		{TestName: "too high", Value: 65536, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateRoutePriority)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate route priorities: %v", es)
+	}
+}
+
+func TestValidateGlobalAddressName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "name", Value: "my-peering-range"},
+		{TestName: "self link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/global/addresses/my-peering-range"},
+
+		// With errors
+		{TestName: "uppercase", Value: "My-range", ExpectError: true},
+		{TestName: "regional self link", Value: "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-central1/addresses/my-range", ExpectError: true},
+		{TestName: "cidr", Value: "10.0.0.0/16", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateGlobalAddressName)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate global address names: %v", es)
+	}
+}
+
+func TestValidateFirestoreDatabaseID(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "default", Value: "(default)"},
+		{TestName: "custom", Value: "my-database"},
+		{TestName: "4 characters", Value: "db01"},
+		{TestName: "30 characters", Value: strings.Repeat("a", 30)},
+
+		// With errors
+		{TestName: "trailing hyphen", Value: "my-database-", ExpectError: true},
+		{TestName: "too short", Value: "db1", ExpectError: true},
+		{TestName: "31 characters", Value: strings.Repeat("a", 31), ExpectError: true},
+		{TestName: "default without parentheses", Value: "default1-", ExpectError: true},
+		{TestName: "uppercase", Value: "MyDatabase", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateFirestoreDatabaseID)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Firestore database ids: %v", es)
+	}
+}
+
+func TestValidateFirestoreType(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "native", Value: "FIRESTORE_NATIVE"},
+		{TestName: "datastore", Value: "DATASTORE_MODE"},
+
+		// With errors
+		{TestName: "lowercase", Value: "datastore_mode", ExpectError: true},
+		{TestName: "unknown", Value: "NATIVE", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateFirestoreType)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate Firestore types: %v", es)
+	}
+}
+
+func TestValidateDNSName(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "domain", Value: "example.com."},
+		{TestName: "subdomain", Value: "www.my-site.example.com."},
+
+		// With errors
+		{TestName: "no trailing dot", Value: "example.com", ExpectError: true},
+		{TestName: "empty label", Value: "example..com.", ExpectError: true},
+		{TestName: "long label", Value: strings.Repeat("a", 64) + ".com.", ExpectError: true},
+		{TestName: "leading hyphen", Value: "-example.com.", ExpectError: true},
+		{TestName: "too long", Value: strings.Repeat("a.", 127) + "com.", ExpectError: true},
+		{TestName: "only a dot", Value: ".", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateDNSName(true))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate DNS names: %v", es)
+	}
+}
+
+func TestValidateManagedCertDomain(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "domain", Value: "example.com"},
+		{TestName: "subdomain", Value: "www.example.com"},
+
+		// With errors
+		{TestName: "wildcard", Value: "*.example.com", ExpectError: true},
+		{TestName: "trailing dot", Value: "example.com.", ExpectError: true},
+		{TestName: "underscore", Value: "my_site.example.com", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateManagedCertDomain)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate managed certificate domains: %v", es)
+	}
+}
+
+func TestValidateCapacityScaler(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0.0},
+		{TestName: "half", Value: 0.5},
+		{TestName: "full", Value: 1.0},
+
+		// With errors
+		{TestName: "over", Value: 1.5, ExpectError: true},
+		{TestName: "negative", Value: -0.1, ExpectError: true},
+		{TestName: "nan", Value: math.NaN(), ExpectError: true},
+		{TestName: "int", Value: 1, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateCapacityScaler)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate capacity scalers: %v", es)
+	}
+}
+
+func TestValidateHostPattern(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "any", Value: "*"},
+		{TestName: "wildcard", Value: "*.example.com"},
+		{TestName: "host", Value: "example.com"},
+		{TestName: "subdomain", Value: "www.example.com"},
+
+		// With errors
+		{TestName: "mid wildcard", Value: "a.*.com", ExpectError: true},
+		{TestName: "two wildcards", Value: "*.*.example.com", ExpectError: true},
+		{TestName: "partial label wildcard", Value: "*example.com", ExpectError: true},
+		{TestName: "empty label", Value: "example..com", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateHostPattern)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate host patterns: %v", es)
+	}
+}
+
+func TestValidatePasswordMinLength(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "positive", Value: 12},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validatePasswordMinLength)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate password min lengths: %v", es)
+	}
+}
+
+func TestValidatePasswordComplexity(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "default", Value: "COMPLEXITY_DEFAULT"},
+		{TestName: "unspecified", Value: "COMPLEXITY_UNSPECIFIED"},
+
+		// With errors
+		{TestName: "lowercase", Value: "complexity_default", ExpectError: true},
+		{TestName: "unknown", Value: "COMPLEXITY_HIGH", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validatePasswordComplexity)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate password complexities: %v", es)
+	}
+}
+
+func TestValidateUtilizationTarget(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "fraction", Value: 0.6},
+		{TestName: "full", Value: 1.0},
+		{TestName: "custom metric", Value: 2.0, ExpectWarning: true},
+
+		// With errors
+		{TestName: "zero", Value: 0.0, ExpectError: true},
+		{TestName: "negative", Value: -0.5, ExpectError: true},
+		{TestName: "nan", Value: math.NaN(), ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateUtilizationTarget)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate utilization targets: %v", es)
+	}
+}
+
+func TestValidateNetworkMTU(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 1300},
+		{TestName: "default", Value: 1460},
+		{TestName: "maximum", Value: 8896},
+		{TestName: "string", Value: "1500"},
+
+		// With errors
+		{TestName: "too small", Value: 1200, ExpectError: true},
+		{TestName: "jumbo", Value: 9000, ExpectError: true},
+		{TestName: "not a number", Value: "auto", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateNetworkMTU)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate network MTUs: %v", es)
+	}
+}
+
+func TestValidateAdvertiseMode(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "default", Value: "DEFAULT"},
+		{TestName: "custom", Value: "CUSTOM"},
+
+		// With errors
+		{TestName: "lowercase", Value: "custom", ExpectError: true},
+		{TestName: "unknown", Value: "ALL_SUBNETS", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateAdvertiseMode)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate advertise modes: %v", es)
+	}
+}
+
+func TestValidateVPNSharedSecret(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "long mixed", Value: "x7Kq2vN9pL4mR8sT3wZ6"},
+		{TestName: "long letters and digits", Value: "abcdefgh12345678"},
+		{TestName: "short", Value: "a1B2c3", ExpectWarning: true},
+		{TestName: "long lowercase", Value: "correcthorsebatterystaple", ExpectWarning: true},
+		{TestName: "long digits", Value: "12345678901234567890", ExpectWarning: true},
+	}
+
+	es := testStringValidationCases(x, validateVPNSharedSecret)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate VPN shared secrets: %v", es)
+	}
+
+	for _, tc := range x {
+		ws, _ := validateVPNSharedSecret(tc.Value, "shared_secret")
+		for _, w := range ws {
+			if strings.Contains(w, tc.Value) {
+				t.Errorf("%s: warning %q contains the secret", tc.TestName, w)
+			}
+		}
+	}
+}
+
+func TestValidateRetryCount(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "zero", Value: 0},
+		{TestName: "maximum", Value: 5},
+
+		// With errors
+		{TestName: "negative", Value: -1, ExpectError: true},
+		{TestName: "too many", Value: 6, ExpectError: true},
+	}
+
+	es := testValidationCases(x, validateRetryCount)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate retry counts: %v", es)
+	}
+}
+
+func TestValidatePrefixLengthRange(t *testing.T) {
+	x := []ValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: 8},
+		{TestName: "typical", Value: 16},
+		{TestName: "maximum", Value: 30},
+		{TestName: "string", Value: "20"},
+
+		// With errors
+		{TestName: "too short", Value: 7, ExpectError: true},
+		{TestName: "too long", Value: 31, ExpectError: true},
+		{TestName: "not a number", Value: "/16", ExpectError: true},
+	}
+
+	es := testValidationCases(x, validatePrefixLengthRange(8, 30))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate prefix lengths: %v", es)
+	}
+}
+
+func TestValidateSecurityPolicyAction(t *testing.T) {
+	x := []StringValidationTestCase{
+		// No errors
+		{TestName: "allow", Value: "allow"},
+		{TestName: "deny 403", Value: "deny(403)"},
+		{TestName: "deny 502", Value: "deny(502)"},
+		{TestName: "rate based ban", Value: "rate_based_ban"},
+
+		// With errors
+		{TestName: "unsupported status", Value: "deny(999)", ExpectError: true},
+		{TestName: "deny without status", Value: "deny", ExpectError: true},
+		{TestName: "upper case", Value: "ALLOW", ExpectError: true},
+		{TestName: "empty", Value: "", ExpectError: true},
+	}
+
+	es := testStringValidationCases(x, validateSecurityPolicyAction)
+	if len(es) > 0 {
+		t.Errorf("Failed to validate security policy actions: %v", es)
+	}
+}